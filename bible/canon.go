@@ -0,0 +1,143 @@
+package bible
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// protestantOldTestament holds the 39 books of the Hebrew scriptures
+// in the order used by the KJV and the other openbible.com texts.
+// Book names match the names used in those text files, so "Psalm" and
+// not "Psalms".
+var protestantOldTestament []string = []string{
+	"Genesis", "Exodus", "Leviticus", "Numbers", "Deuteronomy",
+	"Joshua", "Judges", "Ruth", "1 Samuel", "2 Samuel",
+	"1 Kings", "2 Kings", "1 Chronicles", "2 Chronicles", "Ezra",
+	"Nehemiah", "Esther", "Job", "Psalm", "Proverbs",
+	"Ecclesiastes", "Song of Solomon", "Isaiah", "Jeremiah", "Lamentations",
+	"Ezekiel", "Daniel", "Hosea", "Joel", "Amos",
+	"Obadiah", "Jonah", "Micah", "Nahum", "Habakkuk",
+	"Zephaniah", "Haggai", "Zechariah", "Malachi",
+}
+
+// newTestament holds the 27 books shared by every canon we know about
+var newTestament []string = []string{
+	"Matthew", "Mark", "Luke", "John", "Acts",
+	"Romans", "1 Corinthians", "2 Corinthians", "Galatians", "Ephesians",
+	"Philippians", "Colossians", "1 Thessalonians", "2 Thessalonians", "1 Timothy",
+	"2 Timothy", "Titus", "Philemon", "Hebrews", "James",
+	"1 Peter", "2 Peter", "1 John", "2 John", "3 John",
+	"Jude", "Revelation",
+}
+
+// insertAfter returns a copy of books with extra inserted right after
+// the book named after.  If after is not found, extra goes at the end.
+func insertAfter(books []string, after string, extra ...string) []string {
+	i := slices.Index(books, after)
+	if i < 0 {
+		return append(slices.Clone(books), extra...)
+	}
+	return slices.Concat(books[:i+1], extra, books[i+1:])
+}
+
+// catholicOldTestament adds the deuterocanonical books to the Protestant
+// Old Testament.  Catholic texts usually carry the Greek additions inside
+// Esther and Daniel, but some texts split them out as separate books,
+// so those names are listed too and only show up if a text has them.
+func catholicOldTestament() []string {
+	books := insertAfter(protestantOldTestament, "Nehemiah", "Tobit", "Judith")
+	books = insertAfter(books, "Esther", "Additions to Esther", "1 Maccabees", "2 Maccabees")
+	books = insertAfter(books, "Song of Solomon", "Wisdom", "Sirach")
+	books = insertAfter(books, "Lamentations", "Baruch", "Letter of Jeremiah")
+	books = insertAfter(books, "Daniel", "Prayer of Azariah", "Susanna", "Bel and the Dragon")
+	return books
+}
+
+// orthodoxOldTestament adds the books the Eastern Orthodox churches
+// read on top of the Catholic deuterocanon.
+func orthodoxOldTestament() []string {
+	books := insertAfter(catholicOldTestament(), "2 Chronicles", "Prayer of Manasseh", "1 Esdras")
+	books = insertAfter(books, "2 Maccabees", "3 Maccabees", "4 Maccabees")
+	books = insertAfter(books, "Psalm", "Psalm 151")
+	return books
+}
+
+// ethiopianOldTestament adds the books of the Ethiopian Orthodox
+// Tewahedo broader canon on top of the Orthodox books.
+func ethiopianOldTestament() []string {
+	books := insertAfter(orthodoxOldTestament(), "Deuteronomy", "Jubilees", "Enoch")
+	books = insertAfter(books, "4 Maccabees", "1 Meqabyan", "2 Meqabyan", "3 Meqabyan")
+	books = insertAfter(books, "Letter of Jeremiah", "4 Baruch")
+	return books
+}
+
+// CanonNames are the values accepted by the -canon flag
+var CanonNames []string = []string{"protestant", "catholic", "orthodox", "ethiopian", "custom"}
+
+// CanonBooks returns the books of the named canon in canonical order.
+// For the custom canon the books come from customBooks, and if that is
+// empty, from the loaded ropes in the order their books first appear.
+func CanonBooks(canon string, customBooks []string, ropes []*Rope) ([]string, error) {
+	switch strings.ToLower(canon) {
+	case "protestant":
+		return slices.Concat(protestantOldTestament, newTestament), nil
+	case "catholic":
+		return slices.Concat(catholicOldTestament(), newTestament), nil
+	case "orthodox":
+		return slices.Concat(orthodoxOldTestament(), newTestament), nil
+	case "ethiopian":
+		return slices.Concat(ethiopianOldTestament(), newTestament), nil
+	case "custom":
+		if len(customBooks) > 0 {
+			return customBooks, nil
+		}
+		var books []string
+		for _, myRope := range ropes {
+			for _, book := range myRope.Books {
+				if !slices.Contains(books, book) {
+					books = append(books, book)
+				}
+			}
+		}
+		return books, nil
+	}
+	return nil, fmt.Errorf("unknown canon %q, choose one of %v", canon, CanonNames)
+}
+
+// IsNewTestament reports whether book is one of the books of the New
+// Testament, which every canon shares
+func IsNewTestament(book string) bool {
+	return slices.Contains(newTestament, book)
+}
+
+// ValidBooksFor returns the books of canon that are present in at least
+// one of the loaded ropes, keeping canonical order
+func ValidBooksFor(canon []string, ropes []*Rope) []string {
+	var books []string
+	for _, book := range canon {
+		for _, myRope := range ropes {
+			if myRope.HasBook(book) {
+				books = append(books, book)
+				break
+			}
+		}
+	}
+	return books
+}
+
+// BooksOutsideCanon returns books found in the loaded ropes that are not
+// part of canon, sorted by name, so we can tell the user they exist
+func BooksOutsideCanon(canon []string, ropes []*Rope) []string {
+	var books []string
+	for _, myRope := range ropes {
+		for _, book := range myRope.Books {
+			if !slices.Contains(canon, book) && !slices.Contains(books, book) {
+				books = append(books, book)
+			}
+		}
+	}
+	sort.Strings(books)
+	return books
+}
@@ -0,0 +1,230 @@
+package bible
+
+import (
+	"bytes"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// CatalogEntry describes one bible that can be loaded
+type CatalogEntry struct {
+	// Code is the short name used to pick the bible, like kjv
+	Code     string `json:"code"`
+	Title    string `json:"title"`
+	Language string `json:"language,omitempty"`
+	License  string `json:"license,omitempty"`
+	Year     int    `json:"year,omitempty"`
+	// Versification is the name of the scheme its verses are numbered in, like kjv or vulgate
+	Versification string `json:"versification,omitempty"`
+	// Canon is the canon whose books it has, like protestant or catholic
+	Canon string `json:"canon,omitempty"`
+	// Format is how the text is laid out; only "openbible", one
+	// "Book C:V<tab>text" line per verse, is understood today
+	Format string `json:"format,omitempty"`
+	// Checksum is the SHA-256 of the text, as "sha256:" and hex digits
+	Checksum string `json:"checksum,omitempty"`
+	// URL is where the text is, an http(s) URL or a local path
+	URL string `json:"url"`
+	// Source is the catalog the entry came from
+	Source string `json:"-"`
+}
+
+// Catalog is a list of bibles that can be loaded, in the order they were listed
+type Catalog struct {
+	Version int            `json:"version"`
+	Entries []CatalogEntry `json:"translations"`
+}
+
+// builtinCatalogJSON is the catalog of public domain bibles from openbible.com that ships with the package
+//
+//go:embed catalog.json
+var builtinCatalogJSON []byte
+
+// BuiltinCatalog returns the catalog that ships with the package
+func BuiltinCatalog() *Catalog {
+	catalog, err := ParseCatalog(builtinCatalogJSON, "builtin")
+	if err != nil {
+		// only possible if catalog.json itself is broken
+		panic(err)
+	}
+	return catalog
+}
+
+// ParseCatalog parses a catalog.  JSON catalogs look like catalog.json;
+// anything else is read as the older flat format of "title = url" lines,
+// whose entries get their code from the URL and nothing else.
+func ParseCatalog(data []byte, source string) (*Catalog, error) {
+	catalog := &Catalog{}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := json.Unmarshal(trimmed, catalog); err != nil {
+			return nil, fmt.Errorf("catalog %s: %w", source, err)
+		}
+	} else {
+		for _, line := range strings.Split(string(data), "\n") {
+			parts := strings.SplitN(line, "=", 2) // Split only on the first '='
+			if len(parts) == 2 {
+				url := strings.TrimSpace(parts[1])
+				catalog.Entries = append(catalog.Entries, CatalogEntry{
+					Code:  TranslationCode(url),
+					Title: strings.TrimSpace(parts[0]),
+					URL:   url,
+				})
+			}
+		}
+	}
+	for i := range catalog.Entries {
+		if catalog.Entries[i].Code == "" {
+			return nil, fmt.Errorf("catalog %s: entry %d has no code", source, i+1)
+		}
+		catalog.Entries[i].Source = source
+	}
+	return catalog, nil
+}
+
+// LoadCatalog reads a catalog from an http(s) URL or a local file
+func LoadCatalog(location string) (*Catalog, error) {
+	var data []byte
+	if isURL(location) {
+		text, err := FetchBibleTextFromUrl(location)
+		if err != nil {
+			return nil, err
+		}
+		data = []byte(text)
+	} else {
+		var err error
+		if data, err = os.ReadFile(location); err != nil {
+			return nil, err
+		}
+	}
+	return ParseCatalog(data, location)
+}
+
+// isURL reports whether location is an http or https URL rather than a path
+func isURL(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
+
+// MergeCatalogs combines catalogs into one.  Entries with the same code
+// are merged field by field, with fields set by later catalogs winning,
+// so a user's catalog can correct or add to the built-in one.
+func MergeCatalogs(catalogs ...*Catalog) *Catalog {
+	merged := &Catalog{Version: 1}
+	for _, catalog := range catalogs {
+		for _, entry := range catalog.Entries {
+			i := slices.IndexFunc(merged.Entries, func(e CatalogEntry) bool { return strings.EqualFold(e.Code, entry.Code) })
+			if i < 0 {
+				merged.Entries = append(merged.Entries, entry)
+				continue
+			}
+			merged.Entries[i] = mergeEntry(merged.Entries[i], entry)
+		}
+	}
+	return merged
+}
+
+// mergeEntry returns base with every field that override sets replaced
+func mergeEntry(base, override CatalogEntry) CatalogEntry {
+	for _, field := range []struct{ to, from *string }{
+		{&base.Title, &override.Title}, {&base.Language, &override.Language}, {&base.License, &override.License},
+		{&base.Versification, &override.Versification}, {&base.Canon, &override.Canon}, {&base.Format, &override.Format},
+		{&base.Checksum, &override.Checksum}, {&base.URL, &override.URL}, {&base.Source, &override.Source},
+	} {
+		if *field.from != "" {
+			*field.to = *field.from
+		}
+	}
+	if override.Year != 0 {
+		base.Year = override.Year
+	}
+	return base
+}
+
+// Find returns the entry with the given code, ignoring case
+func (c *Catalog) Find(code string) (CatalogEntry, bool) {
+	for _, entry := range c.Entries {
+		if strings.EqualFold(entry.Code, strings.TrimSpace(code)) {
+			return entry, true
+		}
+	}
+	return CatalogEntry{}, false
+}
+
+// Filter returns the entries whose language is language and whose license
+// contains license, ignoring case.  An empty language or license matches
+// every entry.
+func (c *Catalog) Filter(language, license string) []CatalogEntry {
+	var entries []CatalogEntry
+	for _, entry := range c.Entries {
+		if language != "" && !strings.EqualFold(entry.Language, language) {
+			continue
+		}
+		if license != "" && !strings.Contains(strings.ToLower(entry.License), strings.ToLower(license)) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// VerifyChecksum checks text against the entry's checksum.  Entries
+// without a checksum pass.
+func (e CatalogEntry) VerifyChecksum(text string) error {
+	if e.Checksum == "" {
+		return nil
+	}
+	sum := sha256.Sum256([]byte(text))
+	want := strings.ToLower(strings.TrimPrefix(e.Checksum, "sha256:"))
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return fmt.Errorf("%s: checksum is sha256:%s, the catalog expects sha256:%s", e.Code, got, want)
+	}
+	return nil
+}
+
+// FetchText gets the entry's text from its URL or path and checks it
+// against the entry's checksum
+func (e CatalogEntry) FetchText() (string, error) {
+	if e.Format != "" && e.Format != "openbible" {
+		return "", fmt.Errorf("%s: text format %q is not supported", e.Code, e.Format)
+	}
+	var text string
+	var err error
+	if isURL(e.URL) {
+		text, err = FetchBibleTextFromUrl(e.URL)
+	} else {
+		text, err = FetchBibleTextFromFile(e.URL)
+	}
+	if err != nil {
+		return "", err
+	}
+	return text, e.VerifyChecksum(text)
+}
+
+// Translation parses text, fetched for this entry, into a Translation
+// numbered in the entry's versification, or one guessed from the title
+// when the entry does not name one
+func (e CatalogEntry) Translation(text string) (*Translation, error) {
+	rope, err := ReadBibleIntoRope(text)
+	if err != nil {
+		return nil, err
+	}
+	return e.TranslationOf(rope)
+}
+
+// TranslationOf is Translation for text that is already parsed into rope
+func (e CatalogEntry) TranslationOf(rope *Rope) (*Translation, error) {
+	translation := NewTranslation(e.Code, e.Title, rope)
+	if e.Versification != "" {
+		versification, ok := LookupVersification(e.Versification)
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown versification %q", e.Code, e.Versification)
+		}
+		translation.Versification = versification
+	}
+	return translation, nil
+}
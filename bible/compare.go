@@ -0,0 +1,38 @@
+package bible
+
+// VerseResult is one verse of one translation in a comparison.  Its JSON
+// form is the stable schema the API and the structured output use.
+type VerseResult struct {
+	Reference   string `json:"reference"`
+	Translation string `json:"translation"`
+	Title       string `json:"title"`
+	Text        string `json:"text"`
+	Found       bool   `json:"found"`
+	// Resolved are the verses the translation holds ref under, when it
+	// numbers it differently from the scheme ref was given in
+	Resolved []VerseRef `json:"-"`
+	// HasBook is false when the translation lacks the whole book
+	HasBook bool `json:"-"`
+}
+
+// Compare looks up ref, which is numbered in scheme, in every translation
+// and returns one result per translation, in the same order
+func Compare(translations []*Translation, ref VerseRef, scheme *Versification) []VerseResult {
+	var results []VerseResult
+	for _, translation := range translations {
+		text, resolvedRefs, found := translation.LookupVerse(ref, scheme)
+		result := VerseResult{
+			Reference:   ref.String(),
+			Translation: translation.Code,
+			Title:       translation.Title,
+			Text:        text,
+			Found:       found,
+			HasBook:     translation.Rope.HasBook(ref.Book),
+		}
+		if found && (len(resolvedRefs) > 1 || resolvedRefs[0] != ref) {
+			result.Resolved = resolvedRefs
+		}
+		results = append(results, result)
+	}
+	return results
+}
@@ -0,0 +1,68 @@
+package bible
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// loadFixtures loads the small bibles in testdata, which number their
+// verses like the KJV, the Hebrew and the Vulgate
+func loadFixtures(t *testing.T) []*Translation {
+	t.Helper()
+	var translations []*Translation
+	for _, name := range []string{"kjv.txt", "jps.txt", "drb.txt"} {
+		path := filepath.Join("testdata", name)
+		text, err := FetchBibleTextFromFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		title := map[string]string{"kjv.txt": "King James Bible", "jps.txt": "JPS Tanakh 1917", "drb.txt": "Douay-Rheims Bible"}[name]
+		translation, err := LoadTranslation(TranslationCode(path), title, text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		translations = append(translations, translation)
+	}
+	return translations
+}
+
+func TestCompare(t *testing.T) {
+	translations := loadFixtures(t)
+	type result struct {
+		found, hasBook bool
+		resolved       []VerseRef
+	}
+	tests := []struct {
+		ref    VerseRef
+		scheme *Versification
+		want   []result // kjv, jps, drb
+	}{
+		{VerseRef{"Genesis", 1, 1}, KJV, []result{{true, true, nil}, {true, true, nil}, {true, true, nil}}},
+		{VerseRef{"Malachi", 4, 5}, KJV, []result{{true, true, nil}, {true, true, []VerseRef{{"Malachi", 3, 23}}}, {true, true, nil}}},
+		{VerseRef{"Malachi", 3, 23}, Hebrew, []result{{true, true, []VerseRef{{"Malachi", 4, 5}}}, {true, true, nil}, {true, true, []VerseRef{{"Malachi", 4, 5}}}}},
+		{VerseRef{"Psalm", 51, 1}, KJV, []result{{true, true, nil}, {true, true, []VerseRef{{"Psalm", 51, 3}}}, {true, true, []VerseRef{{"Psalm", 50, 3}}}}},
+		{VerseRef{"John", 3, 16}, KJV, []result{{true, true, nil}, {false, false, nil}, {true, true, nil}}},
+		{VerseRef{"3 John", 1, 14}, KJV, []result{{true, true, nil}, {false, false, nil}, {true, true, []VerseRef{{"3 John", 1, 14}, {"3 John", 1, 15}}}}},
+		{VerseRef{"Genesis", 1, 4}, KJV, []result{{false, true, nil}, {false, true, nil}, {false, true, nil}}},
+	}
+	for _, test := range tests {
+		results := Compare(translations, test.ref, test.scheme)
+		if len(results) != len(translations) {
+			t.Fatalf("Compare(%s) gave %d results, want %d", test.ref, len(results), len(translations))
+		}
+		for i, got := range results {
+			want := test.want[i]
+			if got.Reference != test.ref.String() || got.Translation != translations[i].Code || got.Title != translations[i].Title {
+				t.Errorf("Compare(%s)[%d] is labelled %q %q %q", test.ref, i, got.Reference, got.Translation, got.Title)
+			}
+			if got.Found != want.found || got.HasBook != want.hasBook || !slices.Equal(got.Resolved, want.resolved) {
+				t.Errorf("Compare(%s) in %s: found %v, has book %v, resolved %v, want %v, %v, %v",
+					test.ref, got.Translation, got.Found, got.HasBook, got.Resolved, want.found, want.hasBook, want.resolved)
+			}
+			if got.Found == (got.Text == "") {
+				t.Errorf("Compare(%s) in %s: found %v with text %q", test.ref, got.Translation, got.Found, got.Text)
+			}
+		}
+	}
+}
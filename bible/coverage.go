@@ -0,0 +1,98 @@
+package bible
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Coverage is how one translation's verses compare with a reference
+// translation, with every verse mapped into the KJV numbering first so
+// that differences in versification are not reported as gaps
+type Coverage struct {
+	Title string
+	// Verses is how many verses the translation has
+	Verses int
+	// Missing are verses the reference has but this translation does not
+	Missing []VerseRef
+	// Extra are verses this translation has but the reference does not
+	Extra []VerseRef
+}
+
+// KJVVerses returns the set of verses in the translation, numbered in the KJV scheme
+func (t *Translation) KJVVerses() map[VerseRef]bool {
+	verses := make(map[VerseRef]bool)
+	for book, chapters := range t.Rope.Segments {
+		for chapter, chapterVerses := range chapters {
+			for verse := range chapterVerses {
+				for _, kjvRef := range t.Versification.ToKJV(VerseRef{book, chapter, verse}) {
+					verses[kjvRef] = true
+				}
+			}
+		}
+	}
+	return verses
+}
+
+// SortVerseRefs sorts refs with books in the order of bookOrder, books
+// not in bookOrder last by name, then by chapter and verse
+func SortVerseRefs(refs []VerseRef, bookOrder []string) {
+	bookIndex := func(book string) int {
+		if i := slices.Index(bookOrder, book); i >= 0 {
+			return i
+		}
+		return len(bookOrder)
+	}
+	slices.SortFunc(refs, func(a, b VerseRef) int {
+		return cmp.Or(
+			cmp.Compare(bookIndex(a.Book), bookIndex(b.Book)),
+			strings.Compare(a.Book, b.Book),
+			cmp.Compare(a.Chapter, b.Chapter),
+			cmp.Compare(a.Verse, b.Verse),
+		)
+	})
+}
+
+// CoverageReport compares every translation with reference and returns
+// one Coverage per translation, with its verse lists in bookOrder
+func CoverageReport(translations []*Translation, reference *Translation, bookOrder []string) []Coverage {
+	referenceVerses := reference.KJVVerses()
+	var report []Coverage
+	for _, translation := range translations {
+		verses := translation.KJVVerses()
+		coverage := Coverage{Title: translation.Title, Verses: len(verses)}
+		for ref := range referenceVerses {
+			if !verses[ref] {
+				coverage.Missing = append(coverage.Missing, ref)
+			}
+		}
+		for ref := range verses {
+			if !referenceVerses[ref] {
+				coverage.Extra = append(coverage.Extra, ref)
+			}
+		}
+		SortVerseRefs(coverage.Missing, bookOrder)
+		SortVerseRefs(coverage.Extra, bookOrder)
+		report = append(report, coverage)
+	}
+	return report
+}
+
+// FormatVerseRefs joins refs into one line, leaving out the book and
+// chapter when they are the same as the reference before, like
+// "Matthew 17:21, 18:11, Mark 7:16"
+func FormatVerseRefs(refs []VerseRef) string {
+	var parts []string
+	for i, ref := range refs {
+		switch {
+		case i > 0 && refs[i-1].Book == ref.Book && refs[i-1].Chapter == ref.Chapter:
+			parts = append(parts, fmt.Sprintf("%d", ref.Verse))
+		case i > 0 && refs[i-1].Book == ref.Book:
+			parts = append(parts, fmt.Sprintf("%d:%d", ref.Chapter, ref.Verse))
+		default:
+			parts = append(parts, ref.String())
+		}
+	}
+	return strings.Join(parts, ", ")
+}
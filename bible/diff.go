@@ -0,0 +1,73 @@
+package bible
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DiffOp is one run of words in a word diff between two verses.
+// Op is "equal" for words both have, "delete" for words only the first
+// has and "insert" for words only the second has.
+type DiffOp struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// diffKey is the form words are compared in, so that "Light," and
+// "light" count as the same word
+func diffKey(word string) string {
+	return strings.ToLower(strings.TrimFunc(word, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}))
+}
+
+// DiffWords compares two verses word by word, using the longest common
+// subsequence of their words, and returns the runs of equal, deleted and
+// inserted words in order.  Deleted and inserted runs keep the original
+// words of a and b, equal runs keep the words of b.
+func DiffWords(a, b string) []DiffOp {
+	aWords, bWords := strings.Fields(a), strings.Fields(b)
+	// common[i][j] is the length of the longest common subsequence of aWords[i:] and bWords[j:]
+	common := make([][]int, len(aWords)+1)
+	for i := range common {
+		common[i] = make([]int, len(bWords)+1)
+	}
+	for i := len(aWords) - 1; i >= 0; i-- {
+		for j := len(bWords) - 1; j >= 0; j-- {
+			if diffKey(aWords[i]) == diffKey(bWords[j]) {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+	var ops []DiffOp
+	add := func(op, word string) {
+		if len(ops) > 0 && ops[len(ops)-1].Op == op {
+			ops[len(ops)-1].Text += " " + word
+			return
+		}
+		ops = append(ops, DiffOp{op, word})
+	}
+	i, j := 0, 0
+	for i < len(aWords) && j < len(bWords) {
+		switch {
+		case diffKey(aWords[i]) == diffKey(bWords[j]):
+			add("equal", bWords[j])
+			i, j = i+1, j+1
+		case common[i+1][j] >= common[i][j+1]:
+			add("delete", aWords[i])
+			i++
+		default:
+			add("insert", bWords[j])
+			j++
+		}
+	}
+	for ; i < len(aWords); i++ {
+		add("delete", aWords[i])
+	}
+	for ; j < len(bWords); j++ {
+		add("insert", bWords[j])
+	}
+	return ops
+}
@@ -0,0 +1,35 @@
+// Package bible loads bible texts and compares them verse by verse.
+//
+// A bible text is one verse per line, like the files at
+// https://openbible.com/textfiles/, with the book, chapter and verse, a
+// tab, and then the verse:
+//
+//	Genesis 1:1	In the beginning God created the heaven and the earth.
+//
+// ReadBibleIntoRope parses such a text into a Rope, and LoadTranslation
+// wraps a Rope with the code, title and versification of the bible it
+// came from.  References typed by people, like "Matt 5:3-12", are
+// resolved with ParseReference, and a verse is compared across
+// translations with Compare, which maps the verse into each
+// translation's own versification first.  SearchVerses finds verses by
+// their words and DiffWords compares two verses word by word.
+//
+// A typical use looks like this:
+//
+//	text, err := bible.FetchBibleTextFromUrl("https://openbible.com/textfiles/kjv.txt")
+//	if err != nil {
+//		return err
+//	}
+//	kjv, err := bible.LoadTranslation("kjv", "King James Bible", text)
+//	if err != nil {
+//		return err
+//	}
+//	books, _ := bible.CanonBooks("protestant", nil, nil)
+//	passage, err := bible.ParseReference("John 3:16", books)
+//	if err != nil {
+//		return err
+//	}
+//	for _, result := range bible.Compare([]*bible.Translation{kjv}, passage.Start(), bible.KJV) {
+//		fmt.Println(result.Title, result.Text)
+//	}
+package bible
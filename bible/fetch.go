@@ -0,0 +1,76 @@
+package bible
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// FetchBibleUrls retrieves the http url argument with http.Get,
+// gets entire body with io.ReadAll and returns a map[string][string]
+// that holds the title-of-bible mapped to the URL where it can be retrieved
+// we want something like this map:
+//
+//	bibleTextTitles := map[string]string{
+//		"Berean Standard Bible":  "https://bereanbible.com/bsb.txt",
+//		"Catholic Public Domain Version": "https://bereanbible.com/cpdv.txt",
+//	}
+func FetchBibleUrls(url string) (map[string]string, error) {
+	fileContent, err := FetchBibleTextFromUrl(url)
+	if err != nil {
+		return nil, err
+	}
+	dataMap := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(fileContent))
+	for scanner.Scan() {
+		line := scanner.Text()
+		parts := strings.SplitN(line, "=", 2) // Split only on the first '='
+		if len(parts) == 2 {
+			key := strings.TrimSpace(parts[0])
+			value := strings.TrimSpace(parts[1])
+			dataMap[key] = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error scanning file content: %w", err)
+	}
+	return dataMap, nil
+}
+
+// FetchBibleTextFromUrl retrieves the http url argument with http.Get,
+// gets entire body with io.ReadAll and returns the bible text as a string
+func FetchBibleTextFromUrl(url string) (string, error) {
+	// Make the HTTP GET request
+	resp, err := http.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("error making HTTP request: %w", err)
+	}
+	defer resp.Body.Close() // Ensure the response body is closed
+
+	// Check for a successful status code
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("received non-OK HTTP status from %s: %s", url, resp.Status)
+	}
+
+	// Read the response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("error reading response body: %w", err)
+	}
+	return string(body), nil
+}
+
+// FetchBibleTextFromFile opens the file at the filePath argument
+// and returns the contents of the file as a string
+func FetchBibleTextFromFile(filePath string) (string, error) {
+	// Read the file content into a byte slice
+	contentBytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("error reading file: %w", err)
+	}
+	// Convert the byte slice to a string and return it
+	return string(contentBytes), nil
+}
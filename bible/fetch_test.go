@@ -0,0 +1,73 @@
+package bible
+
+import (
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newFixtureServer serves the files in testdata, plus a catalog of them
+// in the flat "title = url" format at /bibles.txt
+func newFixtureServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	var server *httptest.Server
+	mux.HandleFunc("/bibles.txt", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("King James Bible = " + server.URL + "/kjv.txt\n\nDouay-Rheims Bible=" + server.URL + "/drb.txt\nnot an entry\n"))
+	})
+	mux.Handle("/", http.FileServer(http.Dir("testdata")))
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchBibleUrls(t *testing.T) {
+	server := newFixtureServer(t)
+	got, err := FetchBibleUrls(server.URL + "/bibles.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"King James Bible":   server.URL + "/kjv.txt",
+		"Douay-Rheims Bible": server.URL + "/drb.txt",
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("FetchBibleUrls = %v, want %v", got, want)
+	}
+	if _, err := FetchBibleUrls(server.URL + "/missing.txt"); err == nil {
+		t.Error("FetchBibleUrls of a missing file did not fail")
+	}
+}
+
+func TestFetchBibleTextFromUrl(t *testing.T) {
+	server := newFixtureServer(t)
+	want, err := os.ReadFile(filepath.Join("testdata", "kjv.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := FetchBibleTextFromUrl(server.URL + "/kjv.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Errorf("FetchBibleTextFromUrl gave %d bytes, want the %d of testdata/kjv.txt", len(got), len(want))
+	}
+	_, err = FetchBibleTextFromUrl(server.URL + "/missing.txt")
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("FetchBibleTextFromUrl of a missing file: err = %v, want a 404", err)
+	}
+	server.Close()
+	if _, err := FetchBibleTextFromUrl(server.URL + "/kjv.txt"); err == nil {
+		t.Error("FetchBibleTextFromUrl of a closed server did not fail")
+	}
+}
+
+func TestFetchBibleTextFromFile(t *testing.T) {
+	if _, err := FetchBibleTextFromFile(filepath.Join("testdata", "missing.txt")); err == nil {
+		t.Error("FetchBibleTextFromFile of a missing file did not fail")
+	}
+}
@@ -0,0 +1,84 @@
+package bible
+
+import (
+	"slices"
+)
+
+// FirstVerse returns the first verse of the passage that any of the ropes has
+func (p Passage) FirstVerse(ropes []*Rope) (VerseRef, bool) {
+	refs := p.VerseRefs(ropes)
+	if len(refs) == 0 {
+		return VerseRef{}, false
+	}
+	return refs[0], true
+}
+
+// LastVerse returns the last verse of the passage that any of the ropes has
+func (p Passage) LastVerse(ropes []*Rope) (VerseRef, bool) {
+	refs := p.VerseRefs(ropes)
+	if len(refs) == 0 {
+		return VerseRef{}, false
+	}
+	return refs[len(refs)-1], true
+}
+
+// NextChapter returns the chapter after book chapter, moving on to the
+// first chapter of the next book in books when book has no more chapters.
+// It returns false after the last chapter of the last book.
+func NextChapter(book string, chapter int, books []string, ropes []*Rope) (string, int, bool) {
+	chapters := ChaptersOf(ropes, book)
+	if i := slices.Index(chapters, chapter); i >= 0 && i+1 < len(chapters) {
+		return book, chapters[i+1], true
+	}
+	for i := slices.Index(books, book) + 1; i > 0 && i < len(books); i++ {
+		if chapters := ChaptersOf(ropes, books[i]); len(chapters) > 0 {
+			return books[i], chapters[0], true
+		}
+	}
+	return "", 0, false
+}
+
+// PrevChapter returns the chapter before book chapter, moving back to the
+// last chapter of the previous book in books when chapter is the first.
+// It returns false before the first chapter of the first book.
+func PrevChapter(book string, chapter int, books []string, ropes []*Rope) (string, int, bool) {
+	chapters := ChaptersOf(ropes, book)
+	if i := slices.Index(chapters, chapter); i > 0 {
+		return book, chapters[i-1], true
+	}
+	for i := slices.Index(books, book) - 1; i >= 0; i-- {
+		if chapters := ChaptersOf(ropes, books[i]); len(chapters) > 0 {
+			return books[i], chapters[len(chapters)-1], true
+		}
+	}
+	return "", 0, false
+}
+
+// NextVerse returns the verse after ref, crossing into the next chapter,
+// and the next book in books, when ref ends its chapter
+func NextVerse(ref VerseRef, books []string, ropes []*Rope) (VerseRef, bool) {
+	verses := VersesOf(ropes, ref.Book, ref.Chapter)
+	if i := slices.Index(verses, ref.Verse); i >= 0 && i+1 < len(verses) {
+		return VerseRef{ref.Book, ref.Chapter, verses[i+1]}, true
+	}
+	book, chapter, ok := NextChapter(ref.Book, ref.Chapter, books, ropes)
+	if !ok {
+		return VerseRef{}, false
+	}
+	return VerseRef{book, chapter, VersesOf(ropes, book, chapter)[0]}, true
+}
+
+// PrevVerse returns the verse before ref, crossing back into the previous
+// chapter, and the previous book in books, when ref starts its chapter
+func PrevVerse(ref VerseRef, books []string, ropes []*Rope) (VerseRef, bool) {
+	verses := VersesOf(ropes, ref.Book, ref.Chapter)
+	if i := slices.Index(verses, ref.Verse); i > 0 {
+		return VerseRef{ref.Book, ref.Chapter, verses[i-1]}, true
+	}
+	book, chapter, ok := PrevChapter(ref.Book, ref.Chapter, books, ropes)
+	if !ok {
+		return VerseRef{}, false
+	}
+	verses = VersesOf(ropes, book, chapter)
+	return VerseRef{book, chapter, verses[len(verses)-1]}, true
+}
@@ -0,0 +1,103 @@
+package bible
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// versePattern matches a verse line, for lines parseVerseLine cannot take apart itself
+var versePattern *regexp.Regexp = regexp.MustCompile(`(.*) ([0-9][0-9]*):([0-9][0-9]*)\t(.*)`)
+
+// ParseVerse extracts and returns a slice of four strings from the argument string
+// it will operate on lines like this one
+// Genesis 1:1     In the beginning God created the heaven and the earth.
+// to extract four strings that represent these entities:
+// book chapterNumber verseNumber verse
+// The whole line comes first, like regexp.FindStringSubmatch, and a line
+// that is not a verse gives nil.
+func ParseVerse(line string) []string {
+	if book, chapter, verse, text, ok := splitVerseLine(line); ok {
+		return []string{line, book, chapter, verse, text}
+	}
+	return versePattern.FindStringSubmatch(line)
+}
+
+// splitVerseLine is the fast path of ParseVerse for the usual
+// "Book C:V<tab>text" line.  It gives up, leaving the line to
+// versePattern, on anything unusual, such as a tab in the text, where
+// the two might not agree.
+func splitVerseLine(line string) (book, chapter, verse, text string, ok bool) {
+	tab := strings.IndexByte(line, '\t')
+	if tab < 0 || strings.IndexByte(line[tab+1:], '\t') >= 0 || strings.IndexByte(line, '\n') >= 0 {
+		return "", "", "", "", false
+	}
+	head := line[:tab]
+	space := strings.LastIndexByte(head, ' ')
+	if space < 0 {
+		return "", "", "", "", false
+	}
+	chapter, verse, found := strings.Cut(head[space+1:], ":")
+	if !found || !isDigits(chapter) || !isDigits(verse) {
+		return "", "", "", "", false
+	}
+	return head[:space], chapter, verse, line[tab+1:], true
+}
+
+// isDigits reports whether s is one or more of 0-9
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// ReadBibleIntoRope takes a string of an entire bible and returns
+// a pointer to our centerpiece data structure: Rope
+// The first two lines of the bible texts are a title and a blank line,
+// not verses, so they are skipped.
+func ReadBibleIntoRope(bibleOne string) (*Rope, error) {
+	// Create a Rope to hold bible verses
+	myRope := NewRope()
+	// Create a new scanner from a reader of the string
+	scanner := bufio.NewScanner(strings.NewReader(bibleOne))
+	lineCount := 0
+	for scanner.Scan() {
+		line := scanner.Text()
+		lineCount++
+		if lineCount <= 2 {
+			continue
+		}
+		// the fast path first, which saves making a slice for every line
+		book, chapterString, verseString, verse, ok := splitVerseLine(line)
+		if !ok {
+			var mySliceOfVerseLine []string = versePattern.FindStringSubmatch(line)
+			if mySliceOfVerseLine == nil {
+				return myRope, fmt.Errorf("line %d is not a verse: %q", lineCount, line)
+			}
+			book, chapterString, verseString, verse = mySliceOfVerseLine[1], mySliceOfVerseLine[2], mySliceOfVerseLine[3], mySliceOfVerseLine[4]
+		}
+		chapterNumber, err := strconv.Atoi(chapterString)
+		if err != nil {
+			return myRope, fmt.Errorf("line %d: %w", lineCount, err)
+		}
+		verseNumber, err := strconv.Atoi(verseString)
+		if err != nil {
+			return myRope, fmt.Errorf("line %d: %w", lineCount, err)
+		}
+		myRope.AddSegment(book, chapterNumber, verseNumber, verse)
+	}
+
+	// Check for any errors encountered during scanning
+	if err := scanner.Err(); err != nil {
+		return myRope, fmt.Errorf("error reading lines: %w", err)
+	}
+	return myRope, nil
+}
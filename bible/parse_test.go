@@ -0,0 +1,204 @@
+package bible
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// syntheticBible returns a bible text the size of a whole bible: a title
+// line, a blank line and then 66 books of 20 chapters of 24 verses,
+// about 31,700 verses like the 31,102 of the KJV
+func syntheticBible() string {
+	words := strings.Fields("and the LORD said unto Moses, Speak unto the children of Israel, that they bring me an offering: of every man that giveth it willingly with his heart ye shall take my offering.")
+	var b strings.Builder
+	b.WriteString("Synthetic Bible\n\n")
+	for bookIndex, book := range slices.Concat(protestantOldTestament, newTestament) {
+		for chapter := 1; chapter <= 20; chapter++ {
+			for verse := 1; verse <= 24; verse++ {
+				fmt.Fprintf(&b, "%s %d:%d\t", book, chapter, verse)
+				for i := 0; i < 25; i++ {
+					if i > 0 {
+						b.WriteByte(' ')
+					}
+					b.WriteString(words[(bookIndex+chapter*verse+i)%len(words)])
+				}
+				b.WriteByte('\n')
+			}
+		}
+	}
+	return b.String()
+}
+
+func TestParseVerse(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"Genesis 1:1\tIn the beginning God created the heaven and the earth.", []string{"Genesis", "1", "1", "In the beginning God created the heaven and the earth."}},
+		{"Song of Solomon 2:12\tThe flowers appear on the earth;", []string{"Song of Solomon", "2", "12", "The flowers appear on the earth;"}},
+		{"1 John 5:7\tFor there are three", []string{"1 John", "5", "7", "For there are three"}},
+		{"Psalm 119:176\tI have gone astray", []string{"Psalm", "119", "176", "I have gone astray"}},
+		{"Genesis 1:1\t", []string{"Genesis", "1", "1", ""}},
+		{"Genesis 1:1\ttext with\ta tab", []string{"Genesis", "1", "1", "text with\ta tab"}},
+		{"King James Bible", nil},
+		{"", nil},
+		{"Genesis 1:1 no tab", nil},
+		{"Genesis 1\tno verse number", nil},
+		{"Genesis 1:x\tnot a number", nil},
+	}
+	for _, test := range tests {
+		got := ParseVerse(test.line)
+		if test.want == nil {
+			if got != nil {
+				t.Errorf("ParseVerse(%q) = %q, want nil", test.line, got)
+			}
+			continue
+		}
+		if len(got) != 5 || got[0] != test.line || !slices.Equal(got[1:], test.want) {
+			t.Errorf("ParseVerse(%q) = %q, want the line and %q", test.line, got, test.want)
+		}
+	}
+}
+
+func TestReadBibleIntoRope(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		books   []string
+		verses  map[VerseRef]string
+		wantErr string
+	}{
+		{
+			name:   "title and blank line",
+			text:   "King James Bible\n\nGenesis 1:1\tIn the beginning\nGenesis 1:2\tAnd the earth\nExodus 1:1\tNow these\n",
+			books:  []string{"Genesis", "Exodus"},
+			verses: map[VerseRef]string{{"Genesis", 1, 1}: "In the beginning", {"Genesis", 1, 2}: "And the earth", {"Exodus", 1, 1}: "Now these"},
+		},
+		{
+			name:   "no final newline",
+			text:   "Title\n\nJohn 3:16\tFor God so loved",
+			books:  []string{"John"},
+			verses: map[VerseRef]string{{"John", 3, 16}: "For God so loved"},
+		},
+		{
+			name:  "empty",
+			text:  "",
+			books: nil,
+		},
+		{
+			name:    "stray line",
+			text:    "Title\n\nGenesis 1:1\tIn the beginning\nnot a verse\n",
+			wantErr: "line 4 is not a verse",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rope, err := ReadBibleIntoRope(test.text)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("err = %v, want %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(rope.Books, test.books) {
+				t.Errorf("Books = %q, want %q", rope.Books, test.books)
+			}
+			for ref, want := range test.verses {
+				if got, found := rope.GetSegmentContent(ref.Book, ref.Chapter, ref.Verse); !found || got != want {
+					t.Errorf("%s = %q, %v, want %q", ref, got, found, want)
+				}
+			}
+		})
+	}
+}
+
+func TestReadBibleIntoRopeFixtures(t *testing.T) {
+	for _, name := range []string{"kjv.txt", "jps.txt", "drb.txt"} {
+		text, err := FetchBibleTextFromFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rope, err := ReadBibleIntoRope(text)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if _, found := rope.GetSegmentContent("Genesis", 1, 1); !found {
+			t.Errorf("%s: Genesis 1:1 is missing", name)
+		}
+	}
+}
+
+func TestSplitVerseLineAgreesWithPattern(t *testing.T) {
+	lines := []string{
+		"Genesis 1:1\tIn the beginning God created the heaven and the earth.",
+		"Song of Solomon 2:12\tThe flowers appear on the earth;",
+		"1 John 5:7\tFor there are three that bear record in heaven,",
+		"Psalm 119:176\tI have gone astray like a lost sheep;",
+		"Genesis 1:1\t",
+		" 1:1\tno book",
+		"Genesis 1:1\ttext with\ta tab",
+		"Genesis 1:1\ttext then Exodus 2:3\tanother",
+		"Genesis 1:x\tnot a verse",
+		"Genesis 1\tnot a verse",
+		"Genesis1:1\tno space",
+		"Genesis 1:1 no tab",
+		"",
+	}
+	for _, line := range lines {
+		want := versePattern.FindStringSubmatch(line)
+		if book, chapter, verse, text, ok := splitVerseLine(line); ok {
+			if got := []string{line, book, chapter, verse, text}; !slices.Equal(got, want) {
+				t.Errorf("splitVerseLine(%q) = %q, the pattern gives %q", line, got, want)
+			}
+		}
+		if got := ParseVerse(line); !slices.Equal(got, want) {
+			t.Errorf("ParseVerse(%q) = %q, want %q", line, got, want)
+		}
+	}
+}
+
+func BenchmarkParseVerse(b *testing.B) {
+	line := "Song of Solomon 2:12\tThe flowers appear on the earth; the time of the singing of birds is come, and the voice of the turtle is heard in our land;"
+	for b.Loop() {
+		ParseVerse(line)
+	}
+}
+
+func BenchmarkParseVersePattern(b *testing.B) {
+	line := "Song of Solomon 2:12\tThe flowers appear on the earth; the time of the singing of birds is come, and the voice of the turtle is heard in our land;"
+	for b.Loop() {
+		versePattern.FindStringSubmatch(line)
+	}
+}
+
+func BenchmarkReadBibleIntoRope(b *testing.B) {
+	text := syntheticBible()
+	b.SetBytes(int64(len(text)))
+	for b.Loop() {
+		if _, err := ReadBibleIntoRope(text); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeSnapshot(b *testing.B) {
+	text := syntheticBible()
+	rope, err := ReadBibleIntoRope(text)
+	if err != nil {
+		b.Fatal(err)
+	}
+	source := []byte(text)
+	snapshot := EncodeSnapshot(rope, source)
+	b.SetBytes(int64(len(snapshot)))
+	for b.Loop() {
+		if _, err := DecodeSnapshot(snapshot, source); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
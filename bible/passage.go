@@ -0,0 +1,234 @@
+package bible
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// bookAbbreviations are common short names that are not simply the
+// start of the book name, mapped to the book name used in the texts
+var bookAbbreviations map[string]string = map[string]string{
+	"mt": "Matthew", "mk": "Mark", "mrk": "Mark", "lk": "Luke", "jn": "John", "jhn": "John",
+	"ps": "Psalm", "pss": "Psalm", "psalms": "Psalm", "sos": "Song of Solomon", "song of songs": "Song of Solomon",
+	"canticles": "Song of Solomon", "qoheleth": "Ecclesiastes", "phil": "Philippians", "php": "Philippians",
+	"phm": "Philemon", "phlm": "Philemon", "jas": "James", "jud": "Judges", "jdg": "Judges", "jdt": "Judith",
+	"ezk": "Ezekiel", "jl": "Joel", "nah": "Nahum", "sir": "Sirach", "ecclus": "Sirach", "wis": "Wisdom",
+	"1 jn": "1 John", "2 jn": "2 John", "3 jn": "3 John", "1 pt": "1 Peter", "2 pt": "2 Peter",
+	"1 macc": "1 Maccabees", "2 macc": "2 Maccabees", "rev": "Revelation", "revelations": "Revelation",
+}
+
+// normalizeBookName lowercases a book name, drops periods and puts
+// exactly one space after a leading number, so "1Cor." becomes "1 cor"
+func normalizeBookName(name string) string {
+	name = strings.ToLower(strings.ReplaceAll(name, ".", ""))
+	name = strings.Join(strings.Fields(name), " ")
+	if len(name) > 1 && name[0] >= '1' && name[0] <= '4' && name[1] != ' ' {
+		name = name[:1] + " " + name[1:]
+	}
+	return name
+}
+
+// ErrUnknownBook is returned by ResolveBookName, and ParseReference, for a
+// name that matches none of the books
+var ErrUnknownBook = errors.New("not a book we know")
+
+// ResolveBookName turns what the user typed, an exact book name, a
+// common abbreviation or the start of a book name, into one of books.
+// It is an error when the name matches no book or several books.
+func ResolveBookName(name string, books []string) (string, error) {
+	normalized := normalizeBookName(name)
+	if normalized == "" {
+		return "", fmt.Errorf("no book given")
+	}
+	for _, book := range books {
+		if normalizeBookName(book) == normalized {
+			return book, nil
+		}
+	}
+	if book, ok := bookAbbreviations[normalized]; ok && slices.Contains(books, book) {
+		return book, nil
+	}
+	var matches []string
+	for _, book := range books {
+		if strings.HasPrefix(normalizeBookName(book), normalized) {
+			matches = append(matches, book)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("%s is %w", name, ErrUnknownBook)
+	case 1:
+		return matches[0], nil
+	}
+	return "", fmt.Errorf("%s could be any of %s", name, strings.Join(matches, ", "))
+}
+
+// Passage is a run of verses in one book, like Matthew 5:3-12 or Genesis 1.
+// A StartVerse of 0 means from the start of StartChapter and an EndVerse
+// of 0 means to the end of EndChapter.  A Passage with StartChapter 0
+// names only the book.
+type Passage struct {
+	Book         string
+	StartChapter int
+	StartVerse   int
+	EndChapter   int
+	EndVerse     int
+}
+
+// VersePassage returns the passage that is just the verse ref
+func VersePassage(ref VerseRef) Passage {
+	return Passage{ref.Book, ref.Chapter, ref.Verse, ref.Chapter, ref.Verse}
+}
+
+// ChapterPassage returns the passage that is the whole of book chapter
+func ChapterPassage(book string, chapter int) Passage {
+	return Passage{book, chapter, 0, chapter, 0}
+}
+
+// String formats the passage the way people usually write it
+func (p Passage) String() string {
+	switch {
+	case p.StartChapter == 0:
+		return p.Book
+	case p.StartVerse == 0 && p.EndVerse == 0 && p.StartChapter == p.EndChapter:
+		return fmt.Sprintf("%s %d", p.Book, p.StartChapter)
+	case p.StartVerse == 0 && p.EndVerse == 0:
+		return fmt.Sprintf("%s %d-%d", p.Book, p.StartChapter, p.EndChapter)
+	case p.StartChapter == p.EndChapter && p.StartVerse == p.EndVerse:
+		return fmt.Sprintf("%s %d:%d", p.Book, p.StartChapter, p.StartVerse)
+	case p.StartChapter == p.EndChapter:
+		return fmt.Sprintf("%s %d:%d-%d", p.Book, p.StartChapter, p.StartVerse, p.EndVerse)
+	}
+	return fmt.Sprintf("%s %d:%d-%d:%d", p.Book, p.StartChapter, p.StartVerse, p.EndChapter, p.EndVerse)
+}
+
+// Start returns the first verse of the passage, verse 1 when the passage
+// starts at the beginning of a chapter
+func (p Passage) Start() VerseRef {
+	return VerseRef{p.Book, p.StartChapter, max(p.StartVerse, 1)}
+}
+
+// IsSingleVerse reports whether the passage is exactly one verse
+func (p Passage) IsSingleVerse() bool {
+	return p.StartVerse > 0 && p.StartChapter == p.EndChapter && p.StartVerse == p.EndVerse
+}
+
+// referencePattern splits a reference like "1 Cor 13:4-7" into the book
+// and up to four numbers: chapter, verse, end chapter or verse, end verse
+var referencePattern *regexp.Regexp = regexp.MustCompile(`^\s*([1-4]?\s*[A-Za-z][A-Za-z .]*?)\s*(?:(\d+)(?:\s*:\s*(\d+))?(?:\s*[-–]\s*(\d+)(?:\s*:\s*(\d+))?)?)?\s*$`)
+
+// ParseReference parses references such as "Gen", "Gen 1", "Gen 1-3",
+// "Matt 5:3-12", "John 3:16" and "Gen 1:26-2:3", with the book resolved
+// against books
+func ParseReference(input string, books []string) (Passage, error) {
+	matches := referencePattern.FindStringSubmatch(input)
+	if matches == nil {
+		return Passage{}, fmt.Errorf("%q is not a reference like 'John 3:16' or 'Matt 5:3-12'", strings.TrimSpace(input))
+	}
+	book, err := ResolveBookName(matches[1], books)
+	if err != nil {
+		return Passage{}, err
+	}
+	numbers := make([]int, 4)
+	for i, match := range matches[2:] {
+		if match != "" {
+			numbers[i], _ = strconv.Atoi(match)
+			if numbers[i] == 0 {
+				// chapters and verses are numbered from 1
+				return Passage{}, fmt.Errorf("%q is not a reference, as chapters and verses start at 1", strings.TrimSpace(input))
+			}
+		}
+	}
+	passage := Passage{Book: book, StartChapter: numbers[0], StartVerse: numbers[1]}
+	switch {
+	case numbers[0] == 0:
+		// only the book
+	case numbers[2] == 0:
+		passage.EndChapter, passage.EndVerse = numbers[0], numbers[1]
+	case numbers[3] != 0:
+		// Gen 1:26-2:3
+		passage.EndChapter, passage.EndVerse = numbers[2], numbers[3]
+	case numbers[1] != 0:
+		// Matt 5:3-12
+		passage.EndChapter, passage.EndVerse = numbers[0], numbers[2]
+	default:
+		// Gen 1-3
+		passage.EndChapter = numbers[2]
+	}
+	if passage.EndChapter < passage.StartChapter ||
+		(passage.EndChapter == passage.StartChapter && passage.EndVerse != 0 && passage.EndVerse < passage.StartVerse) {
+		return Passage{}, fmt.Errorf("%s ends before it starts", passage)
+	}
+	return passage, nil
+}
+
+// ChaptersOf returns the sorted chapter numbers any of the ropes has for book
+func ChaptersOf(ropes []*Rope, book string) []int {
+	var chapters []int
+	for _, myRope := range ropes {
+		for chapter := range myRope.Segments[book] {
+			if !slices.Contains(chapters, chapter) {
+				chapters = append(chapters, chapter)
+			}
+		}
+	}
+	slices.Sort(chapters)
+	return chapters
+}
+
+// VersesOf returns the sorted verse numbers any of the ropes has for book and chapter
+func VersesOf(ropes []*Rope, book string, chapter int) []int {
+	var verses []int
+	for _, myRope := range ropes {
+		for verse := range myRope.Segments[book][chapter] {
+			if !slices.Contains(verses, verse) {
+				verses = append(verses, verse)
+			}
+		}
+	}
+	slices.Sort(verses)
+	return verses
+}
+
+// FormatNumberRanges joins sorted numbers into one line, with each run
+// of consecutive numbers shortened to its ends, like "1–3, 5, 7–9"
+func FormatNumberRanges(numbers []int) string {
+	var parts []string
+	for i := 0; i < len(numbers); {
+		end := i
+		for end+1 < len(numbers) && numbers[end+1] == numbers[end]+1 {
+			end++
+		}
+		if end > i {
+			parts = append(parts, fmt.Sprintf("%d–%d", numbers[i], numbers[end]))
+		} else {
+			parts = append(parts, strconv.Itoa(numbers[i]))
+		}
+		i = end + 1
+	}
+	return strings.Join(parts, ", ")
+}
+
+// VerseRefs lists, in order, every verse of the passage that any of the ropes has
+func (p Passage) VerseRefs(ropes []*Rope) []VerseRef {
+	var refs []VerseRef
+	for _, chapter := range ChaptersOf(ropes, p.Book) {
+		if chapter < p.StartChapter || chapter > p.EndChapter {
+			continue
+		}
+		for _, verse := range VersesOf(ropes, p.Book, chapter) {
+			if chapter == p.StartChapter && verse < p.StartVerse {
+				continue
+			}
+			if chapter == p.EndChapter && p.EndVerse != 0 && verse > p.EndVerse {
+				continue
+			}
+			refs = append(refs, VerseRef{p.Book, chapter, verse})
+		}
+	}
+	return refs
+}
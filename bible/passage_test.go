@@ -0,0 +1,108 @@
+package bible
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResolveBookName(t *testing.T) {
+	books, err := CanonBooks("catholic", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name, want string
+		wantErr    bool
+	}{
+		{"Genesis", "Genesis", false},
+		{"genesis", "Genesis", false},
+		{"Gen", "Genesis", false},
+		{"gen.", "Genesis", false},
+		{"1Cor", "1 Corinthians", false},
+		{"1 cor.", "1 Corinthians", false},
+		{"Ps", "Psalm", false},
+		{"Psalms", "Psalm", false},
+		{"Mt", "Matthew", false},
+		{"Song of Songs", "Song of Solomon", false},
+		{"Sir", "Sirach", false},
+		{"Jo", "", true},
+		{"Hezekiah", "", true},
+		{"", "", true},
+	}
+	for _, test := range tests {
+		got, err := ResolveBookName(test.name, books)
+		if (err != nil) != test.wantErr || got != test.want {
+			t.Errorf("ResolveBookName(%q) = %q, %v, want %q, error %v", test.name, got, err, test.want, test.wantErr)
+		}
+		if test.name == "Hezekiah" && !errors.Is(err, ErrUnknownBook) {
+			t.Errorf("ResolveBookName(%q) error = %v, want ErrUnknownBook", test.name, err)
+		}
+	}
+}
+
+func TestParseReference(t *testing.T) {
+	books, err := CanonBooks("protestant", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		input   string
+		want    Passage
+		wantErr bool
+	}{
+		{"John 3:16", Passage{"John", 3, 16, 3, 16}, false},
+		{"  jn 3 : 16 ", Passage{"John", 3, 16, 3, 16}, false},
+		{"Matt 5:3-12", Passage{"Matthew", 5, 3, 5, 12}, false},
+		{"Matt 5:3–12", Passage{"Matthew", 5, 3, 5, 12}, false},
+		{"Gen 1", Passage{"Genesis", 1, 0, 1, 0}, false},
+		{"Gen 1-3", Passage{"Genesis", 1, 0, 3, 0}, false},
+		{"Gen 1:26-2:3", Passage{"Genesis", 1, 26, 2, 3}, false},
+		{"1 Cor 13:4-7", Passage{"1 Corinthians", 13, 4, 13, 7}, false},
+		{"Gen", Passage{Book: "Genesis"}, false},
+		{"Gen 3-1", Passage{}, true},
+		{"Matt 5:12-3", Passage{}, true},
+		{"Gen 1:0", Passage{}, true},
+		{"Gen 0", Passage{}, true},
+		{"Gen 1:1-0", Passage{}, true},
+		{"John 3:16 and more", Passage{}, true},
+		{"Tobit 1:1", Passage{}, true},
+		{"", Passage{}, true},
+	}
+	for _, test := range tests {
+		got, err := ParseReference(test.input, books)
+		if (err != nil) != test.wantErr || got != test.want {
+			t.Errorf("ParseReference(%q) = %+v, %v, want %+v, error %v", test.input, got, err, test.want, test.wantErr)
+		}
+	}
+}
+
+func TestPassageString(t *testing.T) {
+	for _, input := range []string{"John 3:16", "Matthew 5:3-12", "Genesis 1", "Genesis 1-3", "Genesis 1:26-2:3", "Genesis"} {
+		passage, err := ParseReference(input, []string{"Genesis", "Matthew", "John"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := passage.String(); got != input {
+			t.Errorf("ParseReference(%q).String() = %q", input, got)
+		}
+	}
+}
+
+func TestFormatNumberRanges(t *testing.T) {
+	tests := []struct {
+		numbers []int
+		want    string
+	}{
+		{nil, ""},
+		{[]int{7}, "7"},
+		{[]int{1, 2, 3, 4, 5}, "1–5"},
+		{[]int{1, 2, 3, 5, 7, 8, 9}, "1–3, 5, 7–9"},
+		{[]int{23, 51}, "23, 51"},
+		{[]int{22, 23}, "22–23"},
+	}
+	for _, test := range tests {
+		if got := FormatNumberRanges(test.numbers); got != test.want {
+			t.Errorf("FormatNumberRanges(%v) = %q, want %q", test.numbers, got, test.want)
+		}
+	}
+}
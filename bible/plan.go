@@ -0,0 +1,165 @@
+package bible
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// kjvChapterCounts are how many chapters each book of the Protestant
+// canon has in the KJV, which the built-in reading plans are made from
+var kjvChapterCounts map[string]int = map[string]int{
+	"Genesis": 50, "Exodus": 40, "Leviticus": 27, "Numbers": 36, "Deuteronomy": 34,
+	"Joshua": 24, "Judges": 21, "Ruth": 4, "1 Samuel": 31, "2 Samuel": 24,
+	"1 Kings": 22, "2 Kings": 25, "1 Chronicles": 29, "2 Chronicles": 36, "Ezra": 10,
+	"Nehemiah": 13, "Esther": 10, "Job": 42, "Psalm": 150, "Proverbs": 31,
+	"Ecclesiastes": 12, "Song of Solomon": 8, "Isaiah": 66, "Jeremiah": 52, "Lamentations": 5,
+	"Ezekiel": 48, "Daniel": 12, "Hosea": 14, "Joel": 3, "Amos": 9,
+	"Obadiah": 1, "Jonah": 4, "Micah": 7, "Nahum": 3, "Habakkuk": 3,
+	"Zephaniah": 3, "Haggai": 2, "Zechariah": 14, "Malachi": 4,
+	"Matthew": 28, "Mark": 16, "Luke": 24, "John": 21, "Acts": 28,
+	"Romans": 16, "1 Corinthians": 16, "2 Corinthians": 13, "Galatians": 6, "Ephesians": 6,
+	"Philippians": 4, "Colossians": 4, "1 Thessalonians": 5, "2 Thessalonians": 3, "1 Timothy": 6,
+	"2 Timothy": 4, "Titus": 3, "Philemon": 1, "Hebrews": 13, "James": 5,
+	"1 Peter": 5, "2 Peter": 3, "1 John": 5, "2 John": 1, "3 John": 1,
+	"Jude": 1, "Revelation": 22,
+}
+
+// chronologicalOrder is every chapter of the Protestant canon in roughly
+// the order the events happened or the books were written: Job among the
+// patriarchs, the psalms and wisdom with David and Solomon, the prophets
+// with the kings they spoke to, and the letters with Acts.  Each entry is
+// a run of whole chapters.
+var chronologicalOrder []Passage = []Passage{
+	{"Genesis", 1, 0, 11, 0}, {"Job", 1, 0, 42, 0}, {"Genesis", 12, 0, 50, 0},
+	{"Exodus", 1, 0, 40, 0}, {"Leviticus", 1, 0, 27, 0}, {"Numbers", 1, 0, 36, 0},
+	{"Deuteronomy", 1, 0, 34, 0}, {"Psalm", 90, 0, 90, 0}, {"Joshua", 1, 0, 24, 0},
+	{"Judges", 1, 0, 21, 0}, {"Ruth", 1, 0, 4, 0}, {"1 Samuel", 1, 0, 31, 0},
+	{"2 Samuel", 1, 0, 24, 0}, {"1 Chronicles", 1, 0, 29, 0}, {"Psalm", 1, 0, 89, 0},
+	{"Psalm", 91, 0, 150, 0}, {"1 Kings", 1, 0, 4, 0}, {"Proverbs", 1, 0, 31, 0},
+	{"Song of Solomon", 1, 0, 8, 0}, {"1 Kings", 5, 0, 11, 0}, {"Ecclesiastes", 1, 0, 12, 0},
+	{"2 Chronicles", 1, 0, 9, 0}, {"1 Kings", 12, 0, 22, 0}, {"2 Chronicles", 10, 0, 20, 0},
+	{"2 Kings", 1, 0, 8, 0}, {"Obadiah", 1, 0, 1, 0}, {"2 Chronicles", 21, 0, 24, 0},
+	{"Joel", 1, 0, 3, 0}, {"2 Kings", 9, 0, 14, 0}, {"Jonah", 1, 0, 4, 0},
+	{"Amos", 1, 0, 9, 0}, {"2 Chronicles", 25, 0, 27, 0}, {"Hosea", 1, 0, 14, 0},
+	{"2 Kings", 15, 0, 16, 0}, {"Isaiah", 1, 0, 39, 0}, {"Micah", 1, 0, 7, 0},
+	{"2 Chronicles", 28, 0, 32, 0}, {"2 Kings", 17, 0, 20, 0}, {"Isaiah", 40, 0, 66, 0},
+	{"2 Kings", 21, 0, 23, 0}, {"2 Chronicles", 33, 0, 35, 0}, {"Nahum", 1, 0, 3, 0},
+	{"Zephaniah", 1, 0, 3, 0}, {"Habakkuk", 1, 0, 3, 0}, {"Jeremiah", 1, 0, 52, 0},
+	{"2 Kings", 24, 0, 25, 0}, {"2 Chronicles", 36, 0, 36, 0}, {"Lamentations", 1, 0, 5, 0},
+	{"Ezekiel", 1, 0, 48, 0}, {"Daniel", 1, 0, 12, 0}, {"Ezra", 1, 0, 6, 0},
+	{"Haggai", 1, 0, 2, 0}, {"Zechariah", 1, 0, 14, 0}, {"Esther", 1, 0, 10, 0},
+	{"Ezra", 7, 0, 10, 0}, {"Nehemiah", 1, 0, 13, 0}, {"Malachi", 1, 0, 4, 0},
+	{"Matthew", 1, 0, 28, 0}, {"Mark", 1, 0, 16, 0}, {"Luke", 1, 0, 24, 0},
+	{"John", 1, 0, 21, 0}, {"Acts", 1, 0, 14, 0}, {"James", 1, 0, 5, 0},
+	{"Galatians", 1, 0, 6, 0}, {"Acts", 15, 0, 18, 0}, {"1 Thessalonians", 1, 0, 5, 0},
+	{"2 Thessalonians", 1, 0, 3, 0}, {"Acts", 19, 0, 19, 0}, {"1 Corinthians", 1, 0, 16, 0},
+	{"2 Corinthians", 1, 0, 13, 0}, {"Romans", 1, 0, 16, 0}, {"Acts", 20, 0, 28, 0},
+	{"Ephesians", 1, 0, 6, 0}, {"Philippians", 1, 0, 4, 0}, {"Colossians", 1, 0, 4, 0},
+	{"Philemon", 1, 0, 1, 0}, {"1 Timothy", 1, 0, 6, 0}, {"Titus", 1, 0, 3, 0},
+	{"1 Peter", 1, 0, 5, 0}, {"Hebrews", 1, 0, 13, 0}, {"2 Timothy", 1, 0, 4, 0},
+	{"2 Peter", 1, 0, 3, 0}, {"Jude", 1, 0, 1, 0}, {"1 John", 1, 0, 5, 0},
+	{"2 John", 1, 0, 1, 0}, {"3 John", 1, 0, 1, 0}, {"Revelation", 1, 0, 22, 0},
+}
+
+// ReadingPlan is the passages to read on each day of a plan, the first
+// day's first
+type ReadingPlan struct {
+	Name  string
+	Title string
+	Days  [][]Passage
+}
+
+// PlanNames are the built-in reading plans
+var PlanNames []string = []string{"year", "nt90", "chronological"}
+
+// BuiltinPlan returns the built-in plan called name: the whole Bible in a
+// year, the New Testament in 90 days, or the whole Bible in a year in
+// the order things happened
+func BuiltinPlan(name string) (*ReadingPlan, error) {
+	switch name {
+	case "year":
+		books, _ := CanonBooks("protestant", nil, nil)
+		return &ReadingPlan{name, "The whole Bible in a year", SpreadChapters(wholeBooks(books), 365)}, nil
+	case "nt90":
+		return &ReadingPlan{name, "The New Testament in 90 days", SpreadChapters(wholeBooks(newTestament), 90)}, nil
+	case "chronological":
+		return &ReadingPlan{name, "The whole Bible in a year, in the order things happened", SpreadChapters(chronologicalOrder, 365)}, nil
+	}
+	return nil, fmt.Errorf("unknown reading plan %q, choose one of %v or a JSON file", name, PlanNames)
+}
+
+// wholeBooks returns the passages that are each of books from its first
+// chapter to its last
+func wholeBooks(books []string) []Passage {
+	var passages []Passage
+	for _, book := range books {
+		passages = append(passages, Passage{book, 1, 0, kjvChapterCounts[book], 0})
+	}
+	return passages
+}
+
+// SpreadChapters divides the chapters of passages, which are runs of whole
+// chapters, among days as evenly as they go, keeping their order.  The
+// chapters of a day that follow on in one book are joined into one
+// passage, like Genesis 1-4.
+func SpreadChapters(passages []Passage, days int) [][]Passage {
+	var chapters []Passage
+	for _, passage := range passages {
+		for chapter := passage.StartChapter; chapter <= passage.EndChapter; chapter++ {
+			chapters = append(chapters, ChapterPassage(passage.Book, chapter))
+		}
+	}
+	days = max(1, min(days, len(chapters)))
+	plan := make([][]Passage, days)
+	for day := range plan {
+		for _, chapter := range chapters[day*len(chapters)/days : (day+1)*len(chapters)/days] {
+			if last := len(plan[day]) - 1; last >= 0 && plan[day][last].Book == chapter.Book && plan[day][last].EndChapter+1 == chapter.StartChapter {
+				plan[day][last].EndChapter = chapter.StartChapter
+				continue
+			}
+			plan[day] = append(plan[day], chapter)
+		}
+	}
+	return plan
+}
+
+// planFile is a reading plan as a JSON file holds it, with the references
+// to read each day, like ["Gen 1-2", "Matt 1"]
+type planFile struct {
+	Title string     `json:"title"`
+	Days  [][]string `json:"days"`
+}
+
+// ParsePlan reads a reading plan from JSON like
+//
+//	{"title": "Gospels in a month", "days": [["Matt 1-3"], ["Matt 4-6"]]}
+//
+// with the books of the references resolved against books
+func ParsePlan(name string, data []byte, books []string) (*ReadingPlan, error) {
+	var file planFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("reading plan %s: %w", name, err)
+	}
+	if len(file.Days) == 0 {
+		return nil, fmt.Errorf("reading plan %s has no days", name)
+	}
+	plan := &ReadingPlan{Name: name, Title: file.Title}
+	if plan.Title == "" {
+		plan.Title = name
+	}
+	for i, references := range file.Days {
+		var day []Passage
+		for _, reference := range references {
+			passage, err := ParseReference(reference, books)
+			if err == nil && passage.StartChapter == 0 {
+				err = fmt.Errorf("%s needs a chapter", reference)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("reading plan %s, day %d: %w", name, i+1, err)
+			}
+			day = append(day, passage)
+		}
+		plan.Days = append(plan.Days, day)
+	}
+	return plan, nil
+}
@@ -0,0 +1,86 @@
+package bible
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBuiltinPlans(t *testing.T) {
+	books, _ := CanonBooks("protestant", nil, nil)
+	for _, test := range []struct {
+		name  string
+		days  int
+		books []string
+	}{
+		{"year", 365, books},
+		{"nt90", 90, newTestament},
+		{"chronological", 365, books},
+	} {
+		plan, err := BuiltinPlan(test.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(plan.Days) != test.days {
+			t.Errorf("%s has %d days, want %d", test.name, len(plan.Days), test.days)
+		}
+		// every chapter of the books is read once
+		read := make(map[VerseRef]int)
+		for _, day := range plan.Days {
+			if len(day) == 0 {
+				t.Errorf("%s has a day with nothing to read", test.name)
+			}
+			for _, passage := range day {
+				for chapter := passage.StartChapter; chapter <= passage.EndChapter; chapter++ {
+					read[VerseRef{passage.Book, chapter, 0}]++
+				}
+			}
+		}
+		chapters := 0
+		for _, book := range test.books {
+			for chapter := 1; chapter <= kjvChapterCounts[book]; chapter++ {
+				if read[VerseRef{book, chapter, 0}] != 1 {
+					t.Errorf("%s reads %s %d %d times", test.name, book, chapter, read[VerseRef{book, chapter, 0}])
+				}
+				chapters++
+			}
+		}
+		if len(read) != chapters {
+			t.Errorf("%s reads %d chapters, want %d", test.name, len(read), chapters)
+		}
+	}
+	if _, err := BuiltinPlan("decade"); err == nil {
+		t.Error("BuiltinPlan(decade) did not fail")
+	}
+}
+
+func TestSpreadChapters(t *testing.T) {
+	plan := SpreadChapters([]Passage{{"Ruth", 1, 0, 4, 0}, {"Jonah", 1, 0, 4, 0}}, 3)
+	var got [][]string
+	for _, day := range plan {
+		var references []string
+		for _, passage := range day {
+			references = append(references, passage.String())
+		}
+		got = append(got, references)
+	}
+	want := [][]string{{"Ruth 1-2"}, {"Ruth 3-4", "Jonah 1"}, {"Jonah 2-4"}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("SpreadChapters = %q, want %q", got, want)
+	}
+}
+
+func TestParsePlan(t *testing.T) {
+	books, _ := CanonBooks("protestant", nil, nil)
+	plan, err := ParsePlan("gospels.json", []byte(`{"title": "Gospels", "days": [["Matt 1-3", "Ps 1"], ["John 3:1-21"]]}`), books)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Title != "Gospels" || len(plan.Days) != 2 || plan.Days[1][0].String() != "John 3:1-21" || plan.Days[0][1].Book != "Psalm" {
+		t.Errorf("ParsePlan = %+v", plan)
+	}
+	for _, data := range []string{`{"days": []}`, `{"days": [["Gen"]]}`, `{"days": [["Hezekiah 1"]]}`, `[`} {
+		if _, err := ParsePlan("bad.json", []byte(data), books); err == nil {
+			t.Errorf("ParsePlan(%s) did not fail", data)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package bible
+
+import (
+	"math/rand"
+	"time"
+)
+
+// CuratedVerses are well-known verses, in KJV numbering, that the verse of
+// the day is chosen from
+var CuratedVerses []VerseRef = []VerseRef{
+	{"Genesis", 1, 1}, {"Genesis", 1, 27}, {"Genesis", 28, 15}, {"Exodus", 14, 14},
+	{"Numbers", 6, 24}, {"Deuteronomy", 6, 5}, {"Deuteronomy", 31, 6}, {"Joshua", 1, 9},
+	{"Ruth", 1, 16}, {"1 Samuel", 16, 7}, {"1 Chronicles", 16, 34}, {"Nehemiah", 8, 10},
+	{"Job", 19, 25}, {"Psalm", 1, 1}, {"Psalm", 19, 14}, {"Psalm", 23, 1},
+	{"Psalm", 27, 1}, {"Psalm", 34, 8}, {"Psalm", 37, 4}, {"Psalm", 46, 1},
+	{"Psalm", 46, 10}, {"Psalm", 51, 10}, {"Psalm", 90, 12}, {"Psalm", 103, 12},
+	{"Psalm", 118, 24}, {"Psalm", 119, 105}, {"Psalm", 121, 1}, {"Psalm", 139, 14},
+	{"Proverbs", 3, 5}, {"Proverbs", 16, 3}, {"Proverbs", 18, 10}, {"Ecclesiastes", 3, 1},
+	{"Isaiah", 9, 6}, {"Isaiah", 26, 3}, {"Isaiah", 40, 31}, {"Isaiah", 41, 10},
+	{"Isaiah", 53, 5}, {"Jeremiah", 29, 11}, {"Lamentations", 3, 22}, {"Micah", 6, 8},
+	{"Habakkuk", 3, 18}, {"Zephaniah", 3, 17}, {"Malachi", 4, 6},
+	{"Matthew", 5, 9}, {"Matthew", 6, 33}, {"Matthew", 11, 28}, {"Matthew", 22, 37},
+	{"Matthew", 28, 20}, {"Mark", 10, 27}, {"Luke", 1, 37}, {"Luke", 6, 31},
+	{"John", 1, 1}, {"John", 3, 16}, {"John", 8, 32}, {"John", 11, 25},
+	{"John", 13, 34}, {"John", 14, 6}, {"John", 14, 27}, {"John", 15, 13},
+	{"Acts", 1, 8}, {"Romans", 5, 8}, {"Romans", 8, 28}, {"Romans", 12, 2},
+	{"Romans", 15, 13}, {"1 Corinthians", 13, 4}, {"1 Corinthians", 13, 13}, {"2 Corinthians", 5, 17},
+	{"2 Corinthians", 12, 9}, {"Galatians", 2, 20}, {"Galatians", 5, 22}, {"Ephesians", 2, 8},
+	{"Ephesians", 4, 32}, {"Philippians", 4, 6}, {"Philippians", 4, 13}, {"Colossians", 3, 23},
+	{"1 Thessalonians", 5, 16}, {"2 Timothy", 1, 7}, {"Hebrews", 11, 1}, {"Hebrews", 13, 8},
+	{"James", 1, 5}, {"1 Peter", 5, 7}, {"1 John", 1, 9}, {"1 John", 4, 8},
+	{"3 John", 1, 2}, {"Revelation", 21, 4},
+}
+
+// VerseOfTheDay returns the verse of verses for the day date falls on,
+// the same wherever and however often it is asked for that day
+func VerseOfTheDay(date time.Time, verses []VerseRef) VerseRef {
+	seed := int64(date.Year()*10000 + int(date.Month())*100 + date.Day())
+	return verses[rand.New(rand.NewSource(seed)).Intn(len(verses))]
+}
+
+// AllVerses returns every verse of books that one of ropes has, in order
+func AllVerses(ropes []*Rope, books []string) []VerseRef {
+	var refs []VerseRef
+	for _, book := range books {
+		for _, chapter := range ChaptersOf(ropes, book) {
+			for _, verse := range VersesOf(ropes, book, chapter) {
+				refs = append(refs, VerseRef{book, chapter, verse})
+			}
+		}
+	}
+	return refs
+}
@@ -0,0 +1,45 @@
+package bible
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCuratedVerses(t *testing.T) {
+	books, _ := CanonBooks("protestant", nil, nil)
+	seen := make(map[VerseRef]bool)
+	for _, ref := range CuratedVerses {
+		if !slices.Contains(books, ref.Book) || ref.Chapter < 1 || ref.Chapter > kjvChapterCounts[ref.Book] || ref.Verse < 1 {
+			t.Errorf("%s is not a verse of the Protestant canon", ref)
+		}
+		if seen[ref] {
+			t.Errorf("%s is curated twice", ref)
+		}
+		seen[ref] = true
+	}
+}
+
+func TestVerseOfTheDay(t *testing.T) {
+	morning := time.Date(2026, 10, 18, 7, 0, 0, 0, time.UTC)
+	evening := time.Date(2026, 10, 18, 23, 0, 0, 0, time.Local)
+	if got, want := VerseOfTheDay(evening, CuratedVerses), VerseOfTheDay(morning, CuratedVerses); got != want {
+		t.Errorf("the verse of the day is %s in the morning and %s in the evening", want, got)
+	}
+	chosen := make(map[VerseRef]bool)
+	for day := range 7 {
+		chosen[VerseOfTheDay(morning.AddDate(0, 0, day), CuratedVerses)] = true
+	}
+	if len(chosen) < 2 {
+		t.Errorf("a week has only the verses %v", chosen)
+	}
+}
+
+func TestAllVerses(t *testing.T) {
+	kjv := FindTranslation(loadFixtures(t), "kjv")
+	got := AllVerses([]*Rope{kjv.Rope}, []string{"Psalm", "John"})
+	want := []VerseRef{{"Psalm", 23, 1}, {"Psalm", 23, 2}, {"Psalm", 51, 1}, {"John", 3, 16}}
+	if !slices.Equal(got, want) {
+		t.Errorf("AllVerses = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,59 @@
+package bible
+
+// RopeSegment represents a segment of the rope.
+// In a real rope, this would likely be a more complex struct
+// possibly containing the actual string data and length.
+type RopeSegment struct {
+	Content string
+	Length  int
+}
+
+// Rope represents the rope data structure.
+// This example uses a simplified nested map for demonstration.
+type Rope struct {
+	// segments: map[segmentID]map[startIndex]map[endIndex]segmentContent
+	// This is a highly simplified representation for demonstration purposes.
+	// A real rope would use a balanced tree structure.
+	Segments map[string]map[int]map[int]string
+	// Books holds the segmentIDs in the order they were first added,
+	// which for a bible text is the order of the books in the file
+	Books []string
+}
+
+// NewRope creates a new empty Rope.
+func NewRope() *Rope {
+	return &Rope{
+		Segments: make(map[string]map[int]map[int]string),
+	}
+}
+
+// AddSegment adds a segment to the rope.
+// In a real rope, this would involve tree manipulation.
+func (r *Rope) AddSegment(segmentID string, startIndex, endIndex int, content string) {
+	if _, ok := r.Segments[segmentID]; !ok {
+		r.Segments[segmentID] = make(map[int]map[int]string)
+		r.Books = append(r.Books, segmentID)
+	}
+	if _, ok := r.Segments[segmentID][startIndex]; !ok {
+		r.Segments[segmentID][startIndex] = make(map[int]string)
+	}
+	r.Segments[segmentID][startIndex][endIndex] = content
+}
+
+// HasBook reports whether the rope holds any verses of book
+func (r *Rope) HasBook(book string) bool {
+	_, ok := r.Segments[book]
+	return ok
+}
+
+// GetSegmentContent retrieves the content of a specific segment.
+func (r *Rope) GetSegmentContent(segmentID string, startIndex, endIndex int) (string, bool) {
+	if segs, ok := r.Segments[segmentID]; ok {
+		if startMap, ok := segs[startIndex]; ok {
+			if content, ok := startMap[endIndex]; ok {
+				return content, true
+			}
+		}
+	}
+	return "", false
+}
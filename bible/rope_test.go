@@ -0,0 +1,42 @@
+package bible
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRope(t *testing.T) {
+	rope := NewRope()
+	rope.AddSegment("Genesis", 1, 1, "In the beginning")
+	rope.AddSegment("Exodus", 1, 1, "Now these")
+	rope.AddSegment("Genesis", 1, 2, "And the earth")
+	rope.AddSegment("Genesis", 1, 1, "In the beginning God")
+
+	if want := []string{"Genesis", "Exodus"}; !slices.Equal(rope.Books, want) {
+		t.Errorf("Books = %q, want %q", rope.Books, want)
+	}
+	tests := []struct {
+		book           string
+		chapter, verse int
+		want           string
+		found          bool
+	}{
+		{"Genesis", 1, 1, "In the beginning God", true},
+		{"Genesis", 1, 2, "And the earth", true},
+		{"Exodus", 1, 1, "Now these", true},
+		{"Genesis", 1, 3, "", false},
+		{"Genesis", 2, 1, "", false},
+		{"Leviticus", 1, 1, "", false},
+	}
+	for _, test := range tests {
+		got, found := rope.GetSegmentContent(test.book, test.chapter, test.verse)
+		if got != test.want || found != test.found {
+			t.Errorf("GetSegmentContent(%q, %d, %d) = %q, %v, want %q, %v", test.book, test.chapter, test.verse, got, found, test.want, test.found)
+		}
+	}
+	for book, want := range map[string]bool{"Genesis": true, "Exodus": true, "Leviticus": false} {
+		if got := rope.HasBook(book); got != want {
+			t.Errorf("HasBook(%q) = %v, want %v", book, got, want)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package bible
+
+import (
+	"strings"
+)
+
+// SearchHit is one verse of one translation that matched a search
+type SearchHit struct {
+	Ref         VerseRef
+	Translation *Translation
+	Text        string
+}
+
+// SearchTerms splits a query into lowercase terms, keeping words inside
+// double quotes together as one phrase, so `"living water" John` is the
+// two terms "living water" and "john"
+func SearchTerms(query string) []string {
+	var terms []string
+	for i, part := range strings.Split(query, `"`) {
+		if i%2 == 1 {
+			if phrase := strings.Join(strings.Fields(strings.ToLower(part)), " "); phrase != "" {
+				terms = append(terms, phrase)
+			}
+			continue
+		}
+		terms = append(terms, strings.Fields(strings.ToLower(part))...)
+	}
+	return terms
+}
+
+// matchesAllTerms reports whether text holds every term, ignoring case
+func matchesAllTerms(text string, terms []string) bool {
+	lowerText := strings.Join(strings.Fields(strings.ToLower(text)), " ")
+	for _, term := range terms {
+		if !strings.Contains(lowerText, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// SearchVerses returns the verses of the translations that contain every
+// term of query, in the order of books, then by translation.  The verses
+// are numbered in each translation's own versification.  A limit above 0
+// stops the search after that many hits.
+func SearchVerses(translations []*Translation, query string, books []string, limit int) []SearchHit {
+	terms := SearchTerms(query)
+	if len(terms) == 0 {
+		return nil
+	}
+	var hits []SearchHit
+	for _, book := range books {
+		for _, translation := range translations {
+			for _, chapter := range ChaptersOf([]*Rope{translation.Rope}, book) {
+				for _, verse := range VersesOf([]*Rope{translation.Rope}, book, chapter) {
+					text := translation.Rope.Segments[book][chapter][verse]
+					if !matchesAllTerms(text, terms) {
+						continue
+					}
+					hits = append(hits, SearchHit{VerseRef{book, chapter, verse}, translation, text})
+					if limit > 0 && len(hits) >= limit {
+						return hits
+					}
+				}
+			}
+		}
+	}
+	return hits
+}
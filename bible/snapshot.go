@@ -0,0 +1,181 @@
+package bible
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// A snapshot is a parsed Rope saved in a compact binary form, so a bible
+// can be loaded again without parsing its text.  It is laid out as
+//
+//	"GBVCSNAP"            magic
+//	uint16                snapshot format version
+//	uint16                ParserVersion when it was written
+//	[32]byte              SHA-256 of the text it was parsed from
+//	payload               the books, chapters and verses, see appendRope
+//	[32]byte              SHA-256 of everything before it
+//
+// with the integers big-endian.
+const snapshotMagic string = "GBVCSNAP"
+
+// snapshotFormat is the version of the layout above
+const snapshotFormat uint16 = 1
+
+// ParserVersion is the version of ReadBibleIntoRope.  It goes up whenever
+// the same text would be parsed into a different rope, which makes every
+// snapshot written before stale.
+const ParserVersion uint16 = 1
+
+// snapshotHeaderSize is the size of the magic, versions and text checksum
+const snapshotHeaderSize int = len(snapshotMagic) + 2 + 2 + sha256.Size
+
+// ErrStaleSnapshot is returned by DecodeSnapshot for a snapshot of some
+// other text, or written by another version of the parser, which should
+// be replaced by parsing the text again
+var ErrStaleSnapshot = errors.New("snapshot is stale")
+
+// EncodeSnapshot returns a snapshot of rope, which was parsed from source
+func EncodeSnapshot(rope *Rope, source []byte) []byte {
+	sourceSum := sha256.Sum256(source)
+	data := []byte(snapshotMagic)
+	data = binary.BigEndian.AppendUint16(data, snapshotFormat)
+	data = binary.BigEndian.AppendUint16(data, ParserVersion)
+	data = append(data, sourceSum[:]...)
+	data = appendRope(data, rope)
+	sum := sha256.Sum256(data)
+	return append(data, sum[:]...)
+}
+
+// appendRope appends the books of rope, in order, to data.  Each book is
+// its name, its number of chapters and then each chapter: its number,
+// its number of verses and each verse's number and text.  Numbers are
+// uvarints and strings are a uvarint length and the bytes.
+func appendRope(data []byte, rope *Rope) []byte {
+	data = binary.AppendUvarint(data, uint64(len(rope.Books)))
+	for _, book := range rope.Books {
+		data = appendSnapshotString(data, book)
+		chapters := rope.Segments[book]
+		data = binary.AppendUvarint(data, uint64(len(chapters)))
+		for _, chapter := range sortedKeys(chapters) {
+			verses := chapters[chapter]
+			data = binary.AppendUvarint(data, uint64(chapter))
+			data = binary.AppendUvarint(data, uint64(len(verses)))
+			for _, verse := range sortedKeys(verses) {
+				data = binary.AppendUvarint(data, uint64(verse))
+				data = appendSnapshotString(data, verses[verse])
+			}
+		}
+	}
+	return data
+}
+
+// sortedKeys returns the keys of m in increasing order
+func sortedKeys[V any](m map[int]V) []int {
+	keys := make([]int, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func appendSnapshotString(data []byte, s string) []byte {
+	data = binary.AppendUvarint(data, uint64(len(s)))
+	return append(data, s...)
+}
+
+// DecodeSnapshot returns the rope saved in data, provided it was parsed
+// from source by this version of the parser.  Otherwise the error is
+// ErrStaleSnapshot, and a damaged snapshot is some other error.
+func DecodeSnapshot(data, source []byte) (*Rope, error) {
+	if len(data) < snapshotHeaderSize+sha256.Size || string(data[:len(snapshotMagic)]) != snapshotMagic {
+		return nil, errors.New("not a snapshot")
+	}
+	body, sum := data[:len(data)-sha256.Size], data[len(data)-sha256.Size:]
+	if bodySum := sha256.Sum256(body); !bytes.Equal(bodySum[:], sum) {
+		return nil, errors.New("snapshot checksum does not match, it is damaged")
+	}
+	header := body[len(snapshotMagic):snapshotHeaderSize]
+	sourceSum := sha256.Sum256(source)
+	if binary.BigEndian.Uint16(header) != snapshotFormat || binary.BigEndian.Uint16(header[2:]) != ParserVersion || !bytes.Equal(header[4:], sourceSum[:]) {
+		return nil, ErrStaleSnapshot
+	}
+
+	reader := snapshotReader{data: body[snapshotHeaderSize:]}
+	rope := NewRope()
+	books := reader.count()
+	for range books {
+		book := reader.string()
+		chapters := reader.count()
+		for range chapters {
+			if reader.err != nil {
+				break
+			}
+			chapter := int(reader.uvarint())
+			verses := reader.count()
+			for range verses {
+				if reader.err != nil {
+					break
+				}
+				verse := int(reader.uvarint())
+				rope.AddSegment(book, chapter, verse, reader.string())
+			}
+		}
+		if reader.err != nil {
+			return nil, reader.err
+		}
+	}
+	if reader.err == nil && len(reader.data) > 0 {
+		reader.err = fmt.Errorf("snapshot has %d bytes after its last book", len(reader.data))
+	}
+	return rope, reader.err
+}
+
+// snapshotReader reads the payload of a snapshot, remembering the first
+// error so every read need not be checked
+type snapshotReader struct {
+	data []byte
+	err  error
+}
+
+// count reads how many books, chapters or verses follow; as each takes a
+// byte at least, a count larger than the bytes left is damage
+func (r *snapshotReader) count() uint64 {
+	n := r.uvarint()
+	if r.err == nil && n > uint64(len(r.data)) {
+		r.err = fmt.Errorf("snapshot counts %d entries in the %d bytes left", n, len(r.data))
+		return 0
+	}
+	return n
+}
+
+func (r *snapshotReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	value, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err = errors.New("snapshot is cut short")
+		return 0
+	}
+	r.data = r.data[n:]
+	return value
+}
+
+func (r *snapshotReader) string() string {
+	length := r.uvarint()
+	if r.err != nil {
+		return ""
+	}
+	if length > uint64(len(r.data)) {
+		r.err = errors.New("snapshot is cut short")
+		return ""
+	}
+	s := string(r.data[:length])
+	r.data = r.data[length:]
+	return s
+}
@@ -0,0 +1,62 @@
+package bible
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	source := []byte("King James Bible\n\nGenesis 1:1\tIn the beginning\nGenesis 1:2\tAnd the earth\nJohn 3:16\tFor God so loved\n")
+	rope, err := ReadBibleIntoRope(string(source))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodeSnapshot(EncodeSnapshot(rope, source), source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ref := range []VerseRef{{"Genesis", 1, 1}, {"Genesis", 1, 2}, {"John", 3, 16}} {
+		got, ok := decoded.GetSegmentContent(ref.Book, ref.Chapter, ref.Verse)
+		want, _ := rope.GetSegmentContent(ref.Book, ref.Chapter, ref.Verse)
+		if !ok || got != want {
+			t.Errorf("%s = %q, %v, want %q", ref, got, ok, want)
+		}
+	}
+	if _, err := DecodeSnapshot(EncodeSnapshot(rope, source), []byte("another text")); !errors.Is(err, ErrStaleSnapshot) {
+		t.Errorf("a snapshot of another text: err = %v, want ErrStaleSnapshot", err)
+	}
+}
+
+func TestDecodeSnapshotRejectsDamage(t *testing.T) {
+	source := []byte("Genesis 1:1\tIn the beginning\n")
+	// snapshot makes a snapshot of source whose payload is payload, with a
+	// good checksum so only the payload is at fault
+	snapshot := func(payload ...byte) []byte {
+		sourceSum := sha256.Sum256(source)
+		data := []byte(snapshotMagic)
+		data = binary.BigEndian.AppendUint16(data, snapshotFormat)
+		data = binary.BigEndian.AppendUint16(data, ParserVersion)
+		data = append(data, sourceSum[:]...)
+		data = append(data, payload...)
+		sum := sha256.Sum256(data)
+		return append(data, sum[:]...)
+	}
+	tests := []struct {
+		name     string
+		snapshot []byte
+	}{
+		{"not a snapshot", []byte("Genesis 1:1\tIn the beginning")},
+		{"a checksum that does not match", append(snapshot(0), 0)},
+		{"a huge count of books", snapshot(binary.AppendUvarint(nil, 1<<60)...)},
+		{"a huge count of chapters", snapshot(append([]byte{1, 1, 'G'}, binary.AppendUvarint(nil, 1<<60)...)...)},
+		{"cut short in a verse", snapshot(1, 1, 'G', 1, 1, 2, 1, 5, 'I')},
+		{"bytes after the last book", snapshot(0, 0)},
+	}
+	for _, test := range tests {
+		if rope, err := DecodeSnapshot(test.snapshot, source); err == nil {
+			t.Errorf("%s: decoded %v, want an error", test.name, rope)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package bible
+
+import (
+	"strings"
+)
+
+// Translation is one loaded bible: its title, its verses, and the
+// versification scheme its verses are numbered in
+type Translation struct {
+	// Code is a short name for the translation, like asv, taken from its file name
+	Code          string
+	Title         string
+	Rope          *Rope
+	Versification *Versification
+}
+
+// LoadTranslation parses text, a whole bible, into a Translation with
+// the given code and title.  The versification is guessed from the title.
+func LoadTranslation(code, title, text string) (*Translation, error) {
+	myRope, err := ReadBibleIntoRope(text)
+	if err != nil {
+		return nil, err
+	}
+	return NewTranslation(code, title, myRope), nil
+}
+
+// NewTranslation makes a Translation of a rope that is already parsed,
+// such as one read from a snapshot.  The versification is guessed from
+// the title.
+func NewTranslation(code, title string, rope *Rope) *Translation {
+	return &Translation{
+		Code:          code,
+		Title:         title,
+		Rope:          rope,
+		Versification: VersificationForTitle(title),
+	}
+}
+
+// TranslationCode makes a short code for a translation from the URL or
+// path it was loaded from, so https://openbible.com/textfiles/asv.txt is asv
+func TranslationCode(source string) string {
+	base := source[strings.LastIndexAny(source, "/\\")+1:]
+	if dot := strings.Index(base, "."); dot > 0 {
+		base = base[:dot]
+	}
+	return strings.ToLower(base)
+}
+
+// FindTranslation returns the translation with the given code, ignoring
+// case, or nil when none of them has it
+func FindTranslation(translations []*Translation, code string) *Translation {
+	for _, translation := range translations {
+		if strings.EqualFold(translation.Code, strings.TrimSpace(code)) {
+			return translation
+		}
+	}
+	return nil
+}
+
+// Ropes returns the rope of every translation, in the same order
+func Ropes(translations []*Translation) []*Rope {
+	ropes := make([]*Rope, 0, len(translations))
+	for _, translation := range translations {
+		ropes = append(ropes, translation.Rope)
+	}
+	return ropes
+}
+
+// LookupVerse returns the text of ref, which is numbered in scheme, from
+// this translation.  When the translation numbers the verse differently,
+// or splits it, every verse it resolves to is joined into the content and
+// the resolved references are returned so they can be shown.
+func (t *Translation) LookupVerse(ref VerseRef, scheme *Versification) (string, []VerseRef, bool) {
+	resolved := ResolveVerse(ref, scheme, t.Versification)
+	var contents []string
+	var foundRefs []VerseRef
+	for _, target := range resolved {
+		if content, found := t.Rope.GetSegmentContent(target.Book, target.Chapter, target.Verse); found {
+			contents = append(contents, content)
+			foundRefs = append(foundRefs, target)
+		}
+	}
+	return strings.Join(contents, " "), foundRefs, len(contents) > 0
+}
@@ -0,0 +1,326 @@
+package bible
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// VerseRef names one verse, like John 3:16
+type VerseRef struct {
+	Book    string
+	Chapter int
+	Verse   int
+}
+
+// String formats the reference the way the bible text files do, Book C:V
+func (v VerseRef) String() string {
+	return fmt.Sprintf("%s %d:%d", v.Book, v.Chapter, v.Verse)
+}
+
+// verseSpan is a run of verses inside one chapter.
+// Last of 0 means the span runs to the end of the chapter.
+type verseSpan struct {
+	Book    string
+	Chapter int
+	First   int
+	Last    int
+}
+
+// contains reports whether ref falls inside the span
+func (s verseSpan) contains(ref VerseRef) bool {
+	return ref.Book == s.Book && ref.Chapter == s.Chapter &&
+		ref.Verse >= s.First && (s.Last == 0 || ref.Verse <= s.Last)
+}
+
+// shiftRule moves the KJV verses in From so that From.First lands on To
+// and the following verses keep counting up from there
+type shiftRule struct {
+	From verseSpan
+	To   VerseRef
+}
+
+// Versification is a verse numbering scheme, described by how it differs
+// from the KJV numbering that the openbible.com texts use.  Any verse not
+// covered by a rule has the same number in both schemes.
+type Versification struct {
+	Name string
+	// shifts renumber runs of KJV verses, e.g. Malachi 4:1-6 is 3:19-24 in the Hebrew bible
+	shifts []shiftRule
+	// splits map one KJV verse onto several verses of this scheme, e.g. 3 John 14 is 14-15 in the Vulgate
+	splits map[VerseRef][]VerseRef
+	// extras are verses of this scheme with no KJV verse at all, like numbered psalm titles
+	extras []verseSpan
+}
+
+// FromKJV returns the verse(s) of this scheme that hold the KJV verse ref
+func (v *Versification) FromKJV(ref VerseRef) []VerseRef {
+	if targets, ok := v.splits[ref]; ok {
+		return targets
+	}
+	for _, rule := range v.shifts {
+		if rule.From.contains(ref) {
+			return []VerseRef{{rule.To.Book, rule.To.Chapter, rule.To.Verse + ref.Verse - rule.From.First}}
+		}
+	}
+	return []VerseRef{ref}
+}
+
+// ToKJV returns the KJV verse(s) that hold the verse ref of this scheme.
+// It returns nothing for verses the KJV does not number, like psalm titles.
+func (v *Versification) ToKJV(ref VerseRef) []VerseRef {
+	for kjvRef, targets := range v.splits {
+		if slices.Contains(targets, ref) {
+			return []VerseRef{kjvRef}
+		}
+	}
+	for _, extra := range v.extras {
+		if extra.contains(ref) {
+			return nil
+		}
+	}
+	for _, rule := range v.shifts {
+		if rule.To.Book != ref.Book || rule.To.Chapter != ref.Chapter || ref.Verse < rule.To.Verse {
+			continue
+		}
+		offset := ref.Verse - rule.To.Verse
+		if rule.From.Last == 0 || rule.From.First+offset <= rule.From.Last {
+			return []VerseRef{{rule.From.Book, rule.From.Chapter, rule.From.First + offset}}
+		}
+	}
+	return []VerseRef{ref}
+}
+
+// ResolveVerse converts ref, numbered in scheme from, into the verse(s)
+// that hold the same text in scheme to.  The result keeps the order of
+// the verses and has no duplicates.
+func ResolveVerse(ref VerseRef, from, to *Versification) []VerseRef {
+	if from == to {
+		return []VerseRef{ref}
+	}
+	var resolved []VerseRef
+	for _, kjvRef := range from.ToKJV(ref) {
+		for _, target := range to.FromKJV(kjvRef) {
+			if !slices.Contains(resolved, target) {
+				resolved = append(resolved, target)
+			}
+		}
+	}
+	return resolved
+}
+
+// shift is shorthand for a shiftRule moving KJV book chapter:first-last to toChapter:toVerse
+func shift(book string, chapter, first, last, toChapter, toVerse int) shiftRule {
+	return shiftRule{verseSpan{book, chapter, first, last}, VerseRef{book, toChapter, toVerse}}
+}
+
+// psalmTitleVerses lists the psalms whose title is numbered as verse 1
+// (or verses 1-2) in the Hebrew, Greek and Latin bibles, pushing every
+// verse of the psalm down by that many
+var psalmTitleVerses map[int]int = map[int]int{
+	3: 1, 4: 1, 5: 1, 6: 1, 7: 1, 8: 1, 9: 1, 12: 1, 13: 1, 18: 1, 19: 1, 20: 1,
+	21: 1, 22: 1, 30: 1, 31: 1, 34: 1, 36: 1, 38: 1, 39: 1, 40: 1, 41: 1, 42: 1,
+	44: 1, 45: 1, 46: 1, 47: 1, 48: 1, 49: 1, 51: 2, 52: 2, 53: 1, 54: 2, 55: 1,
+	56: 1, 57: 1, 58: 1, 59: 1, 60: 2, 61: 1, 62: 1, 63: 1, 64: 1, 65: 1, 67: 1,
+	68: 1, 69: 1, 70: 1, 75: 1, 76: 1, 77: 1, 80: 1, 81: 1, 83: 1, 84: 1, 85: 1,
+	88: 1, 89: 1, 92: 1, 102: 1, 108: 1, 140: 1, 142: 1,
+}
+
+// hebrewPsalms renumbers the psalm titles the way the Masoretic text does
+func hebrewPsalms() ([]shiftRule, []verseSpan) {
+	var shifts []shiftRule
+	var extras []verseSpan
+	for psalm := 1; psalm <= 150; psalm++ {
+		if titleVerses := psalmTitleVerses[psalm]; titleVerses > 0 {
+			shifts = append(shifts, shift("Psalm", psalm, 1, 0, psalm, 1+titleVerses))
+			extras = append(extras, verseSpan{"Psalm", psalm, 1, titleVerses})
+		}
+	}
+	return shifts, extras
+}
+
+// greekPsalms renumbers the psalms the way the Septuagint and the Vulgate
+// do: 9-10 and 114-115 are joined, 116 and 147 are split, so most psalms
+// are one behind the KJV, and titles are numbered as in the Hebrew.
+func greekPsalms() ([]shiftRule, []verseSpan) {
+	var shifts []shiftRule
+	var extras []verseSpan
+	for psalm := 1; psalm <= 150; psalm++ {
+		titleVerses := psalmTitleVerses[psalm]
+		greekPsalm := psalm
+		switch {
+		case psalm == 9:
+			shifts = append(shifts, shift("Psalm", 9, 1, 20, 9, 1+titleVerses))
+		case psalm == 10:
+			shifts = append(shifts, shift("Psalm", 10, 1, 18, 9, 22))
+			continue
+		case psalm == 114:
+			shifts = append(shifts, shift("Psalm", 114, 1, 8, 113, 1))
+			continue
+		case psalm == 115:
+			shifts = append(shifts, shift("Psalm", 115, 1, 18, 113, 9))
+			continue
+		case psalm == 116:
+			shifts = append(shifts, shift("Psalm", 116, 1, 9, 114, 1), shift("Psalm", 116, 10, 19, 115, 1))
+			continue
+		case psalm == 147:
+			shifts = append(shifts, shift("Psalm", 147, 1, 11, 146, 1), shift("Psalm", 147, 12, 20, 147, 1))
+			continue
+		case psalm > 10 && psalm < 147:
+			greekPsalm = psalm - 1
+			fallthrough
+		default:
+			if titleVerses > 0 || greekPsalm != psalm {
+				shifts = append(shifts, shift("Psalm", psalm, 1, 0, greekPsalm, 1+titleVerses))
+			}
+		}
+		if titleVerses > 0 {
+			extras = append(extras, verseSpan{"Psalm", greekPsalm, 1, titleVerses})
+		}
+	}
+	return shifts, extras
+}
+
+// hebrewChapterBreaks are the places where the Masoretic text starts a
+// chapter at a different verse than the KJV
+var hebrewChapterBreaks []shiftRule = []shiftRule{
+	shift("Genesis", 31, 55, 55, 32, 1), shift("Genesis", 32, 1, 0, 32, 2),
+	shift("Exodus", 8, 1, 4, 7, 26), shift("Exodus", 8, 5, 0, 8, 1),
+	shift("Exodus", 22, 1, 1, 21, 37), shift("Exodus", 22, 2, 0, 22, 1),
+	shift("Leviticus", 6, 1, 7, 5, 20), shift("Leviticus", 6, 8, 0, 6, 1),
+	shift("Numbers", 16, 36, 50, 17, 1), shift("Numbers", 17, 1, 0, 17, 16),
+	shift("Numbers", 29, 40, 40, 30, 1), shift("Numbers", 30, 1, 0, 30, 2),
+	shift("Deuteronomy", 12, 32, 32, 13, 1), shift("Deuteronomy", 13, 1, 0, 13, 2),
+	shift("Deuteronomy", 22, 30, 30, 23, 1), shift("Deuteronomy", 23, 1, 0, 23, 2),
+	shift("Deuteronomy", 29, 1, 1, 28, 69), shift("Deuteronomy", 29, 2, 0, 29, 1),
+	shift("1 Samuel", 21, 1, 0, 21, 2),
+	shift("1 Samuel", 23, 29, 29, 24, 1), shift("1 Samuel", 24, 1, 0, 24, 2),
+	shift("2 Samuel", 18, 33, 33, 19, 1), shift("2 Samuel", 19, 1, 0, 19, 2),
+	shift("1 Kings", 4, 21, 34, 5, 1), shift("1 Kings", 5, 1, 0, 5, 15),
+	shift("2 Kings", 11, 21, 21, 12, 1), shift("2 Kings", 12, 1, 0, 12, 2),
+	shift("1 Chronicles", 6, 1, 15, 5, 27), shift("1 Chronicles", 6, 16, 0, 6, 1),
+	shift("2 Chronicles", 2, 1, 1, 1, 18), shift("2 Chronicles", 2, 2, 0, 2, 1),
+	shift("2 Chronicles", 14, 1, 1, 13, 23), shift("2 Chronicles", 14, 2, 0, 14, 1),
+	shift("Nehemiah", 4, 1, 6, 3, 33), shift("Nehemiah", 4, 7, 0, 4, 1),
+	shift("Nehemiah", 9, 38, 38, 10, 1), shift("Nehemiah", 10, 1, 0, 10, 2),
+	shift("Job", 41, 1, 8, 40, 25), shift("Job", 41, 9, 0, 41, 1),
+	shift("Ecclesiastes", 5, 1, 1, 4, 17), shift("Ecclesiastes", 5, 2, 0, 5, 1),
+	shift("Song of Solomon", 6, 13, 13, 7, 1), shift("Song of Solomon", 7, 1, 0, 7, 2),
+	shift("Isaiah", 9, 1, 1, 8, 23), shift("Isaiah", 9, 2, 0, 9, 1),
+	shift("Isaiah", 64, 1, 1, 63, 19), shift("Isaiah", 64, 2, 0, 64, 1),
+	shift("Jeremiah", 9, 1, 1, 8, 23), shift("Jeremiah", 9, 2, 0, 9, 1),
+	shift("Ezekiel", 20, 45, 49, 21, 1), shift("Ezekiel", 21, 1, 0, 21, 6),
+	shift("Daniel", 4, 1, 3, 3, 31), shift("Daniel", 4, 4, 0, 4, 1),
+	shift("Daniel", 5, 31, 31, 6, 1), shift("Daniel", 6, 1, 0, 6, 2),
+	shift("Hosea", 1, 10, 11, 2, 1), shift("Hosea", 2, 1, 0, 2, 3),
+	shift("Hosea", 11, 12, 12, 12, 1), shift("Hosea", 12, 1, 0, 12, 2),
+	shift("Hosea", 13, 16, 16, 14, 1), shift("Hosea", 14, 1, 0, 14, 2),
+	shift("Joel", 2, 28, 32, 3, 1), shift("Joel", 3, 1, 0, 4, 1),
+	shift("Jonah", 1, 17, 17, 2, 1), shift("Jonah", 2, 1, 0, 2, 2),
+	shift("Micah", 5, 1, 1, 4, 14), shift("Micah", 5, 2, 0, 5, 1),
+	shift("Nahum", 1, 15, 15, 2, 1), shift("Nahum", 2, 1, 0, 2, 2),
+	shift("Zechariah", 1, 18, 21, 2, 1), shift("Zechariah", 2, 1, 0, 2, 5),
+	shift("Malachi", 4, 1, 6, 3, 19),
+}
+
+// newKJVVersification is the reference scheme, every verse maps to itself
+func newKJVVersification() *Versification {
+	return &Versification{Name: "kjv"}
+}
+
+// newHebrewVersification is the numbering of the Masoretic text, used by
+// Jewish translations such as the JPS 1917
+func newHebrewVersification() *Versification {
+	shifts, extras := hebrewPsalms()
+	return &Versification{
+		Name:   "mt",
+		shifts: slices.Concat(hebrewChapterBreaks, shifts),
+		splits: map[VerseRef][]VerseRef{
+			{"1 Samuel", 20, 42}: {{"1 Samuel", 20, 42}, {"1 Samuel", 21, 1}},
+		},
+		extras: extras,
+	}
+}
+
+// newSeptuagintVersification is the numbering of the Greek Old Testament,
+// which follows the Hebrew in Joel and Malachi but has its own psalms
+func newSeptuagintVersification() *Versification {
+	shifts, extras := greekPsalms()
+	return &Versification{
+		Name: "lxx",
+		shifts: slices.Concat(shifts, []shiftRule{
+			shift("Joel", 2, 28, 32, 3, 1), shift("Joel", 3, 1, 0, 4, 1),
+			shift("Malachi", 4, 1, 6, 3, 19),
+		}),
+		extras: extras,
+	}
+}
+
+// newVulgateVersification is the numbering of the Latin Vulgate, which
+// the Douay-Rheims and the Catholic Public Domain Version follow.  The
+// Greek additions to Daniel sit in chapter 3, pushing the rest down.
+func newVulgateVersification() *Versification {
+	shifts, extras := greekPsalms()
+	return &Versification{
+		Name: "vulgate",
+		shifts: slices.Concat(shifts, []shiftRule{
+			shift("Daniel", 3, 24, 30, 3, 91),
+			shift("Daniel", 4, 1, 3, 3, 98), shift("Daniel", 4, 4, 0, 4, 1),
+		}),
+		splits: map[VerseRef][]VerseRef{
+			{"3 John", 1, 14}: {{"3 John", 1, 14}, {"3 John", 1, 15}},
+		},
+		extras: append(extras, verseSpan{"Daniel", 3, 24, 90}),
+	}
+}
+
+// The versification schemes we know about
+var (
+	// KJV is the numbering of the King James Version and most English bibles
+	KJV *Versification = newKJVVersification()
+	// Hebrew is the numbering of the Masoretic text
+	Hebrew *Versification = newHebrewVersification()
+	// Septuagint is the numbering of the Greek Old Testament
+	Septuagint *Versification = newSeptuagintVersification()
+	// Vulgate is the numbering of the Latin Vulgate
+	Vulgate *Versification = newVulgateVersification()
+)
+
+// versifications are the schemes we know about, by the name used with the -versification flag
+var versifications map[string]*Versification = map[string]*Versification{
+	KJV.Name:        KJV,
+	Hebrew.Name:     Hebrew,
+	Septuagint.Name: Septuagint,
+	Vulgate.Name:    Vulgate,
+}
+
+// LookupVersification returns the scheme with the given name, ignoring case
+func LookupVersification(name string) (*Versification, bool) {
+	v, ok := versifications[strings.ToLower(name)]
+	return v, ok
+}
+
+// VersificationNames returns the names of the known schemes, sorted
+func VersificationNames() []string {
+	names := make([]string, 0, len(versifications))
+	for name := range versifications {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// VersificationForTitle guesses the scheme of a bible from its title,
+// since the text files themselves do not say
+func VersificationForTitle(title string) *Versification {
+	lowerTitle := strings.ToLower(title)
+	switch {
+	case strings.Contains(lowerTitle, "douay"), strings.Contains(lowerTitle, "catholic public domain"), strings.Contains(lowerTitle, "vulgate"):
+		return Vulgate
+	case strings.Contains(lowerTitle, "jps"), strings.Contains(lowerTitle, "jewish publication"):
+		return Hebrew
+	case strings.Contains(lowerTitle, "septuagint"), strings.Contains(lowerTitle, "brenton"):
+		return Septuagint
+	}
+	return KJV
+}
@@ -0,0 +1,51 @@
+package bible
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestResolveVerse(t *testing.T) {
+	tests := []struct {
+		ref      VerseRef
+		from, to *Versification
+		want     []VerseRef
+	}{
+		{VerseRef{"John", 3, 16}, KJV, Hebrew, []VerseRef{{"John", 3, 16}}},
+		{VerseRef{"Malachi", 4, 5}, KJV, Hebrew, []VerseRef{{"Malachi", 3, 23}}},
+		{VerseRef{"Malachi", 3, 23}, Hebrew, KJV, []VerseRef{{"Malachi", 4, 5}}},
+		{VerseRef{"Malachi", 4, 5}, KJV, Vulgate, []VerseRef{{"Malachi", 4, 5}}},
+		{VerseRef{"Psalm", 23, 1}, KJV, Vulgate, []VerseRef{{"Psalm", 22, 1}}},
+		{VerseRef{"Psalm", 51, 1}, KJV, Hebrew, []VerseRef{{"Psalm", 51, 3}}},
+		{VerseRef{"Psalm", 51, 1}, KJV, Vulgate, []VerseRef{{"Psalm", 50, 3}}},
+		{VerseRef{"Psalm", 50, 3}, Vulgate, Hebrew, []VerseRef{{"Psalm", 51, 3}}},
+		{VerseRef{"3 John", 1, 14}, KJV, Vulgate, []VerseRef{{"3 John", 1, 14}, {"3 John", 1, 15}}},
+		{VerseRef{"Genesis", 31, 55}, KJV, Hebrew, []VerseRef{{"Genesis", 32, 1}}},
+	}
+	for _, test := range tests {
+		if got := ResolveVerse(test.ref, test.from, test.to); !slices.Equal(got, test.want) {
+			t.Errorf("ResolveVerse(%s, %s, %s) = %v, want %v", test.ref, test.from.Name, test.to.Name, got, test.want)
+		}
+	}
+}
+
+func TestLookupVersification(t *testing.T) {
+	for _, name := range VersificationNames() {
+		if v, ok := LookupVersification(name); !ok || v.Name != name {
+			t.Errorf("LookupVersification(%q) = %v, %v", name, v, ok)
+		}
+	}
+	if _, ok := LookupVersification("nonesuch"); ok {
+		t.Error("LookupVersification found nonesuch")
+	}
+	for title, want := range map[string]*Versification{
+		"King James Bible":   KJV,
+		"Douay-Rheims Bible": Vulgate,
+		"JPS Tanakh 1917":    Hebrew,
+		"Brenton Septuagint": Septuagint,
+	} {
+		if got := VersificationForTitle(title); got != want {
+			t.Errorf("VersificationForTitle(%q) = %s, want %s", title, got.Name, want.Name)
+		}
+	}
+}
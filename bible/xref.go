@@ -0,0 +1,147 @@
+package bible
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// osisBooks are the OSIS abbreviations cross reference files name the
+// books of the Protestant canon with, like Gen.1.1
+var osisBooks map[string]string = map[string]string{
+	"Gen": "Genesis", "Exod": "Exodus", "Lev": "Leviticus", "Num": "Numbers", "Deut": "Deuteronomy",
+	"Josh": "Joshua", "Judg": "Judges", "Ruth": "Ruth", "1Sam": "1 Samuel", "2Sam": "2 Samuel",
+	"1Kgs": "1 Kings", "2Kgs": "2 Kings", "1Chr": "1 Chronicles", "2Chr": "2 Chronicles", "Ezra": "Ezra",
+	"Neh": "Nehemiah", "Esth": "Esther", "Job": "Job", "Ps": "Psalm", "Prov": "Proverbs",
+	"Eccl": "Ecclesiastes", "Song": "Song of Solomon", "Isa": "Isaiah", "Jer": "Jeremiah", "Lam": "Lamentations",
+	"Ezek": "Ezekiel", "Dan": "Daniel", "Hos": "Hosea", "Joel": "Joel", "Amos": "Amos",
+	"Obad": "Obadiah", "Jonah": "Jonah", "Mic": "Micah", "Nah": "Nahum", "Hab": "Habakkuk",
+	"Zeph": "Zephaniah", "Hag": "Haggai", "Zech": "Zechariah", "Mal": "Malachi",
+	"Matt": "Matthew", "Mark": "Mark", "Luke": "Luke", "John": "John", "Acts": "Acts",
+	"Rom": "Romans", "1Cor": "1 Corinthians", "2Cor": "2 Corinthians", "Gal": "Galatians", "Eph": "Ephesians",
+	"Phil": "Philippians", "Col": "Colossians", "1Thess": "1 Thessalonians", "2Thess": "2 Thessalonians", "1Tim": "1 Timothy",
+	"2Tim": "2 Timothy", "Titus": "Titus", "Phlm": "Philemon", "Heb": "Hebrews", "Jas": "James",
+	"1Pet": "1 Peter", "2Pet": "2 Peter", "1John": "1 John", "2John": "2 John", "3John": "3 John",
+	"Jude": "Jude", "Rev": "Revelation",
+}
+
+// CrossReference is a passage related to a verse, with the votes of the
+// readers who agreed it is related; the more votes, the closer it is
+type CrossReference struct {
+	From  VerseRef
+	To    Passage
+	Votes int
+}
+
+// CrossReferences are the cross references of each verse, in KJV
+// numbering, the most voted for first
+type CrossReferences map[VerseRef][]CrossReference
+
+// ParseCrossReferences reads cross references from the tab separated
+// file OpenBible.info publishes, with lines like
+//
+//	Gen.1.1	Prov.8.22-Prov.8.30	59
+//
+// after a header line.  Lines starting with # are comments.
+func ParseCrossReferences(r io.Reader) (CrossReferences, error) {
+	xrefs := make(CrossReferences)
+	scanner := bufio.NewScanner(r)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "From Verse") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("line %d: want a verse, a passage and votes, separated by tabs", number)
+		}
+		from, err := parseOSISVerse(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", number, err)
+		}
+		to, err := parseOSISPassage(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", number, err)
+		}
+		votes, err := strconv.Atoi(strings.TrimSpace(fields[2]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %q is not a number of votes", number, fields[2])
+		}
+		xrefs[from] = append(xrefs[from], CrossReference{from, to, votes})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(xrefs) == 0 {
+		return nil, fmt.Errorf("there are no cross references")
+	}
+	for _, refs := range xrefs {
+		slices.SortStableFunc(refs, func(a, b CrossReference) int { return b.Votes - a.Votes })
+	}
+	return xrefs, nil
+}
+
+// parseOSISVerse parses a verse written like Gen.1.1
+func parseOSISVerse(osis string) (VerseRef, error) {
+	parts := strings.Split(strings.TrimSpace(osis), ".")
+	if len(parts) != 3 {
+		return VerseRef{}, fmt.Errorf("%q is not a verse like Gen.1.1", osis)
+	}
+	book, ok := osisBooks[parts[0]]
+	if !ok {
+		return VerseRef{}, fmt.Errorf("%q is not a book of the Protestant canon", parts[0])
+	}
+	chapter, err := strconv.Atoi(parts[1])
+	if err != nil || chapter < 1 {
+		return VerseRef{}, fmt.Errorf("%q is not a verse like Gen.1.1", osis)
+	}
+	verse, err := strconv.Atoi(parts[2])
+	if err != nil || verse < 1 {
+		return VerseRef{}, fmt.Errorf("%q is not a verse like Gen.1.1", osis)
+	}
+	return VerseRef{book, chapter, verse}, nil
+}
+
+// parseOSISPassage parses a verse like Gen.1.1 or a run of verses like
+// Prov.8.22-Prov.8.30.  A run into another book is cut short at the first
+// verse, as a passage is in one book.
+func parseOSISPassage(osis string) (Passage, error) {
+	first, last, isRun := strings.Cut(osis, "-")
+	start, err := parseOSISVerse(first)
+	if err != nil {
+		return Passage{}, err
+	}
+	end := start
+	if isRun {
+		if end, err = parseOSISVerse(last); err != nil {
+			return Passage{}, err
+		}
+		if end.Book != start.Book {
+			end = start
+		}
+	}
+	return Passage{start.Book, start.Chapter, start.Verse, end.Chapter, end.Verse}, nil
+}
+
+// For returns the cross references of every verse of refs, which are in
+// KJV numbering, the most voted for first.  A passage related to several
+// of the verses is listed once, with the most votes it has.
+func (x CrossReferences) For(refs []VerseRef) []CrossReference {
+	var found []CrossReference
+	for _, ref := range refs {
+		for _, xref := range x[ref] {
+			i := slices.IndexFunc(found, func(have CrossReference) bool { return have.To == xref.To })
+			switch {
+			case i < 0:
+				found = append(found, xref)
+			case xref.Votes > found[i].Votes:
+				found[i] = xref
+			}
+		}
+	}
+	slices.SortStableFunc(found, func(a, b CrossReference) int { return b.Votes - a.Votes })
+	return found
+}
@@ -0,0 +1,61 @@
+package bible
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+const crossReferencesTSV = "From Verse\tTo Verse\tVotes\t#www.openbible.info CC-BY 2024-07-01\n" +
+	"John.3.16\tRom.5.8\t193\n" +
+	"John.3.16\t1John.4.9-1John.4.10\t150\n" +
+	"John.3.16\tJohn.1.14\t-2\n" +
+	"John.3.17\tRom.5.8\t40\n" +
+	"John.3.17\tJohn.12.47\t60\n" +
+	"Gen.1.1\tProv.8.22-Prov.8.30\t59\n" +
+	"Mal.4.6\tMal.4.6-Matt.1.1\t3\n"
+
+func TestParseCrossReferences(t *testing.T) {
+	xrefs, err := ParseCrossReferences(strings.NewReader(crossReferencesTSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, xref := range xrefs.For([]VerseRef{{"John", 3, 16}}) {
+		got = append(got, xref.To.String())
+	}
+	if want := []string{"Romans 5:8", "1 John 4:9-10", "John 1:14"}; !slices.Equal(got, want) {
+		t.Errorf("the cross references of John 3:16 are %q, want %q", got, want)
+	}
+
+	// a passage related to several verses is listed once, with its most votes
+	got = nil
+	for _, xref := range xrefs.For([]VerseRef{{"John", 3, 16}, {"John", 3, 17}}) {
+		got = append(got, xref.To.String())
+	}
+	if want := []string{"Romans 5:8", "1 John 4:9-10", "John 12:47", "John 1:14"}; !slices.Equal(got, want) {
+		t.Errorf("the cross references of John 3:16-17 are %q, want %q", got, want)
+	}
+
+	if got := xrefs.For([]VerseRef{{"Malachi", 4, 6}}); len(got) != 1 || got[0].To.String() != "Malachi 4:6" {
+		t.Errorf("a run into another book is %v, want it cut short at Malachi 4:6", got)
+	}
+	if got := xrefs.For([]VerseRef{{"Psalm", 23, 1}}); len(got) != 0 {
+		t.Errorf("Psalm 23:1 has the cross references %v, want none", got)
+	}
+}
+
+func TestParseCrossReferencesErrors(t *testing.T) {
+	for _, test := range []struct{ input, want string }{
+		{"Gen.1.1\tJohn.1.1\n", "want a verse, a passage and votes"},
+		{"Genesis.1.1\tJohn.1.1\t3\n", `"Genesis" is not a book`},
+		{"Gen.1\tJohn.1.1\t3\n", `"Gen.1" is not a verse`},
+		{"Gen.1.1\tJohn.1.1\tmany\n", `"many" is not a number of votes`},
+		{"From Verse\tTo Verse\tVotes\n", "there are no cross references"},
+	} {
+		_, err := ParseCrossReferences(strings.NewReader(test.input))
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("ParseCrossReferences(%q) = %v, want an error with %q", test.input, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/botanyhelp/goBibleVerseComparer/bible"
+)
+
+// markCommands are the words that may follow mark.  Anything else after
+// mark, like 'mark 1', is the book of Mark.
+var markCommands []string = []string{"add", "list", "go", "tag", "note", "rm"}
+
+// bookmark is a saved reference, in the numbering references are typed in
+type bookmark struct {
+	Reference string `json:"reference"`
+	// Tags are kept without the # they are typed with
+	Tags  []string  `json:"tags,omitempty"`
+	Note  string    `json:"note,omitempty"`
+	Added time.Time `json:"added"`
+}
+
+// bookmarksPath is the file the bookmarks are kept in
+func bookmarksPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bookmarks.json"), nil
+}
+
+// loadBookmarks reads the bookmarks at path; there are none until the
+// file is written
+func loadBookmarks(path string) ([]bookmark, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var marks []bookmark
+	if err := json.Unmarshal(data, &marks); err != nil {
+		return nil, fmt.Errorf("bookmarks file %s: %w", path, err)
+	}
+	return marks, nil
+}
+
+// saveBookmarks writes marks to path, making its directory if need be
+func saveBookmarks(path string, marks []bookmark) error {
+	data, err := json.MarshalIndent(marks, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// splitTags takes the words that start with # out of words, and returns
+// them, lowercase and without the #, and the other words
+func splitTags(words []string) ([]string, []string) {
+	var tags, rest []string
+	for _, word := range words {
+		if tag := strings.ToLower(strings.TrimLeft(word, "#")); strings.HasPrefix(word, "#") && tag != "" {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		} else {
+			rest = append(rest, word)
+		}
+	}
+	return tags, rest
+}
+
+// addTags adds the tags have does not have yet to it
+func addTags(have, tags []string) []string {
+	for _, tag := range tags {
+		if !slices.Contains(have, tag) {
+			have = append(have, tag)
+		}
+	}
+	return have
+}
+
+// formatTags writes tags as they are typed, like "#love #gospel"
+func formatTags(tags []string) string {
+	var words []string
+	for _, tag := range tags {
+		words = append(words, "#"+tag)
+	}
+	return strings.Join(words, " ")
+}
+
+// bookmarkNumber parses the number list gives a bookmark
+func bookmarkNumber(arg string, marks []bookmark) (int, error) {
+	number, err := strconv.Atoi(arg)
+	if err != nil || number < 1 || number > len(marks) {
+		return 0, fmt.Errorf("%q is not the number of a bookmark, 'mark list' numbers them", arg)
+	}
+	return number - 1, nil
+}
+
+// completeMarkCommands completes the word after mark
+func completeMarkCommands(r *repl, args string) []string {
+	if strings.Contains(args, " ") {
+		return nil
+	}
+	return completeWords(args, markCommands)
+}
+
+func (r *repl) markCommand(args string) (bool, error) {
+	if r.bookmarksPath == "" {
+		return false, fmt.Errorf("bookmarks cannot be kept, as there is no config directory")
+	}
+	marks, err := loadBookmarks(r.bookmarksPath)
+	if err != nil {
+		return false, err
+	}
+	subcommand, args, _ := strings.Cut(args, " ")
+	args = strings.TrimSpace(args)
+	switch subcommand {
+	case "add":
+		return false, r.markAdd(marks, args)
+	case "list":
+		return false, r.markList(marks, args)
+	case "go":
+		return r.markGo(marks, args)
+	case "tag", "note", "rm":
+		numberArg, rest, _ := strings.Cut(args, " ")
+		i, err := bookmarkNumber(numberArg, marks)
+		if err != nil {
+			return false, err
+		}
+		switch subcommand {
+		case "tag":
+			tags, others := splitTags(strings.Fields(rest))
+			if len(tags) == 0 || len(others) > 0 {
+				return false, fmt.Errorf("give the tags to add, like 'mark tag %d #hope'", i+1)
+			}
+			marks[i].Tags = addTags(marks[i].Tags, tags)
+			fmt.Fprintf(r.out, "%s is tagged %s\n", marks[i].Reference, formatTags(marks[i].Tags))
+		case "note":
+			marks[i].Note = strings.TrimSpace(rest)
+			fmt.Fprintf(r.out, "Noted %s\n", marks[i].Reference)
+		case "rm":
+			fmt.Fprintf(r.out, "Removed the bookmark of %s\n", marks[i].Reference)
+			marks = slices.Delete(marks, i, i+1)
+		}
+		return false, saveBookmarks(r.bookmarksPath, marks)
+	}
+	return false, fmt.Errorf("unknown mark command %q, use one of %v", subcommand, markCommands)
+}
+
+// markAdd bookmarks the reference args starts with, or the passage shown
+// last, with the #tags in args and the rest of args as its note.  A
+// reference that is bookmarked already gets the tags and note added to it.
+func (r *repl) markAdd(marks []bookmark, args string) error {
+	tags, words := splitTags(strings.Fields(args))
+	passage, words, err := r.leadingPassage(words)
+	if err != nil {
+		return err
+	}
+	note := strings.Join(words, " ")
+
+	reference := passage.String()
+	i := slices.IndexFunc(marks, func(mark bookmark) bool { return mark.Reference == reference })
+	if i < 0 {
+		marks = append(marks, bookmark{Reference: reference, Added: time.Now().UTC().Truncate(time.Second)})
+		i = len(marks) - 1
+		fmt.Fprintf(r.out, "Bookmarked %s as %d\n", reference, i+1)
+	} else {
+		fmt.Fprintf(r.out, "%s is bookmarked already, as %d\n", reference, i+1)
+	}
+	marks[i].Tags = addTags(marks[i].Tags, tags)
+	if note != "" {
+		marks[i].Note = note
+	}
+	return saveBookmarks(r.bookmarksPath, marks)
+}
+
+// markList lists the bookmarks, or those with the #tag in args
+func (r *repl) markList(marks []bookmark, args string) error {
+	tags, others := splitTags(strings.Fields(args))
+	if len(others) > 0 {
+		return fmt.Errorf("give tags to list the bookmarks with, like 'mark list #hope'")
+	}
+	w := tabwriter.NewWriter(r.out, 0, 4, 2, ' ', 0)
+	listed := 0
+	for i, mark := range marks {
+		if !hasTags(mark.Tags, tags) {
+			continue
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", i+1, r.style.heading(mark.Reference), mark.Added.Local().Format(time.DateOnly), formatTags(mark.Tags), mark.Note)
+		listed++
+	}
+	if listed == 0 {
+		if len(tags) > 0 {
+			fmt.Fprintf(r.out, "No bookmarks are tagged %s\n", formatTags(tags))
+		} else {
+			fmt.Fprintln(r.out, "There are no bookmarks yet; 'mark add John 3:16' makes one")
+		}
+		return nil
+	}
+	return w.Flush()
+}
+
+// hasTags reports whether have has every one of tags
+func hasTags(have, tags []string) bool {
+	for _, tag := range tags {
+		if !slices.Contains(have, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// markGo shows the bookmark numbered in args, or every bookmark with the
+// #tags in it, across the translations shown
+func (r *repl) markGo(marks []bookmark, args string) (bool, error) {
+	var chosen []bookmark
+	if tags, others := splitTags(strings.Fields(args)); len(tags) > 0 && len(others) == 0 {
+		for _, mark := range marks {
+			if hasTags(mark.Tags, tags) {
+				chosen = append(chosen, mark)
+			}
+		}
+		if len(chosen) == 0 {
+			return false, fmt.Errorf("no bookmarks are tagged %s", formatTags(tags))
+		}
+	} else {
+		i, err := bookmarkNumber(args, marks)
+		if err != nil {
+			return false, err
+		}
+		chosen = append(chosen, marks[i])
+	}
+	for i, mark := range chosen {
+		passage, err := bible.ParseReference(mark.Reference, r.books)
+		if err != nil {
+			return false, fmt.Errorf("bookmark %s: %w", mark.Reference, err)
+		}
+		if r.format == "text" {
+			if i > 0 {
+				fmt.Fprintln(r.out)
+			}
+			if header := strings.TrimSpace(formatTags(mark.Tags) + " " + mark.Note); header != "" {
+				fmt.Fprintln(r.out, r.style.note(header))
+			}
+		}
+		r.showPassage(passage)
+	}
+	return true, nil
+}
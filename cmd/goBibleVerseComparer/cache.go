@@ -0,0 +1,82 @@
+package main
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/botanyhelp/goBibleVerseComparer/bible"
+)
+
+// cachingOff reports whether cacheDir turns the cache off
+func cachingOff(cacheDir string) bool {
+	return cacheDir == "" || cacheDir == "off"
+}
+
+// cachePath is where the cache keeps its kind of file, texts or
+// snapshots, for the bible at location.  The location is part of the name
+// so a catalog pointing a code somewhere new does not get the old file.
+func cachePath(cacheDir, kind, location, extension string) string {
+	locationSum := sha256.Sum256([]byte(location))
+	return filepath.Join(cacheDir, kind, fmt.Sprintf("%s-%x%s", bible.TranslationCode(location), locationSum[:4], extension))
+}
+
+// writeCacheFile saves data at path, only warning when it cannot, since
+// the cache just saves time
+func writeCacheFile(path string, data []byte) {
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err == nil {
+		err = os.WriteFile(path, data, 0o644)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not write the cache file %s: %v\n", path, err)
+	}
+}
+
+// fetchEntryText returns the entry's text, from cacheDir when it was
+// downloaded before and still matches the entry's checksum.  Texts that
+// are downloaded are saved there for next time.  Local files, and every
+// text when cacheDir is "off", are read directly.
+func fetchEntryText(entry bible.CatalogEntry, cacheDir string) (string, error) {
+	if cachingOff(cacheDir) || !strings.HasPrefix(entry.URL, "http") {
+		return entry.FetchText()
+	}
+	path := cachePath(cacheDir, "texts", entry.URL, ".txt")
+	if data, err := os.ReadFile(path); err == nil && entry.VerifyChecksum(string(data)) == nil {
+		return string(data), nil
+	}
+	text, err := entry.FetchText()
+	if err != nil {
+		return "", err
+	}
+	writeCacheFile(path, []byte(text))
+	return text, nil
+}
+
+// readRope parses text, which came from location, into a rope.  The
+// snapshot kept in cacheDir is used instead when it was made from the
+// same text by the same parser, and otherwise it is made anew.
+func readRope(text, location, cacheDir string) (*bible.Rope, error) {
+	if cachingOff(cacheDir) {
+		return bible.ReadBibleIntoRope(text)
+	}
+	path := cachePath(cacheDir, "snapshots", location, ".snap")
+	if data, err := os.ReadFile(path); err == nil {
+		rope, err := bible.DecodeSnapshot(data, []byte(text))
+		if err == nil {
+			return rope, nil
+		}
+		if !errors.Is(err, bible.ErrStaleSnapshot) {
+			fmt.Fprintf(os.Stderr, "Ignoring the snapshot %s: %v\n", path, err)
+		}
+	}
+	rope, err := bible.ReadBibleIntoRope(text)
+	if err != nil {
+		return nil, err
+	}
+	writeCacheFile(path, bible.EncodeSnapshot(rope, []byte(text)))
+	return rope, nil
+}
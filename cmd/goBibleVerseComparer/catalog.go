@@ -0,0 +1,185 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/botanyhelp/goBibleVerseComparer/bible"
+)
+
+// configDir returns the directory this program keeps its own files in,
+// under the user's config directory
+func configDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "goBibleVerseComparer"), nil
+}
+
+// userCatalogPath is the catalog that 'catalog add' writes to
+func userCatalogPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "catalog.json"), nil
+}
+
+// loadCatalogs merges, in increasing priority, the built-in catalog, the
+// catalogs in locations and the user's own catalog.  A remote catalog
+// that cannot be reached is skipped with a warning, since the built-in
+// catalog still works offline.
+func loadCatalogs(locations []string) (*bible.Catalog, error) {
+	catalogs := []*bible.Catalog{bible.BuiltinCatalog()}
+	for _, location := range locations {
+		catalog, err := bible.LoadCatalog(location)
+		if err != nil && strings.HasPrefix(location, "http") {
+			fmt.Fprintf(os.Stderr, "Could not read the catalog at %s, skipping it: %v\n", location, err)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		catalogs = append(catalogs, catalog)
+	}
+	userPath, err := userCatalogPath()
+	if err == nil {
+		if userCatalog, err := bible.LoadCatalog(userPath); err == nil {
+			catalogs = append(catalogs, userCatalog)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return bible.MergeCatalogs(catalogs...), nil
+}
+
+// loadCatalogEntry fetches the bible of entry, or takes it from the cache
+// in cacheDir, and returns it with the number of lines in its text
+func loadCatalogEntry(entry bible.CatalogEntry, cacheDir string) (*bible.Translation, int, error) {
+	text, err := fetchEntryText(entry, cacheDir)
+	if err != nil {
+		return nil, 0, err
+	}
+	rope, err := readRope(text, entry.URL, cacheDir)
+	if err != nil {
+		return nil, 0, err
+	}
+	translation, err := entry.TranslationOf(rope)
+	if err != nil {
+		return nil, 0, err
+	}
+	return translation, strings.Count(text, "\n"), nil
+}
+
+// runCatalogCommand runs 'catalog list' or 'catalog add' with args
+func runCatalogCommand(args []string, config Config) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: catalog list [-lang en] [-license text] [-json] | catalog add -code c -title t -url u [...]")
+	}
+	switch args[0] {
+	case "list":
+		return runCatalogList(args[1:], config)
+	case "add":
+		return runCatalogAdd(args[1:])
+	}
+	return fmt.Errorf("unknown catalog command %q, use list or add", args[0])
+}
+
+// runCatalogList prints the merged catalog, filtered by language and license
+func runCatalogList(args []string, config Config) error {
+	flags := flag.NewFlagSet("catalog list", flag.ExitOnError)
+	flags.String("config", "", "the JSON config file whose catalogs are listed")
+	language := flags.String("lang", "", "only bibles in this language, like en")
+	license := flags.String("license", "", "only bibles whose license contains this, like 'public domain'")
+	asJSON := flags.Bool("json", false, "print the entries as a JSON catalog")
+	flags.Var(&listFlag{list: &config.Catalogs}, "catalog", "a catalog of bibles to use on top of the built-in one, a URL or a file; may be repeated")
+	flags.Parse(args)
+
+	catalog, err := loadCatalogs(config.Catalogs)
+	if err != nil {
+		return err
+	}
+	entries := catalog.Filter(*language, *license)
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(bible.Catalog{Version: 1, Entries: entries})
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "CODE\tTITLE\tLANGUAGE\tLICENSE\tYEAR\tVERSIFICATION\tCANON\tSOURCE")
+	for _, entry := range entries {
+		year := ""
+		if entry.Year != 0 {
+			year = fmt.Sprint(entry.Year)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", entry.Code, entry.Title, entry.Language, entry.License, year, entry.Versification, entry.Canon, entry.Source)
+	}
+	return w.Flush()
+}
+
+// runCatalogAdd adds an entry to the user's catalog, or replaces the
+// entry with the same code
+func runCatalogAdd(args []string) error {
+	flags := flag.NewFlagSet("catalog add", flag.ExitOnError)
+	var entry bible.CatalogEntry
+	flags.StringVar(&entry.Code, "code", "", "short code to pick the bible by, like kjv (required)")
+	flags.StringVar(&entry.Title, "title", "", "full title of the bible (required)")
+	flags.StringVar(&entry.URL, "url", "", "http(s) URL or path of the text (required)")
+	flags.StringVar(&entry.Language, "lang", "", "language, like en")
+	flags.StringVar(&entry.License, "license", "", "license of the text")
+	flags.IntVar(&entry.Year, "year", 0, "year the translation was published")
+	flags.StringVar(&entry.Versification, "versification", "", fmt.Sprintf("verse numbering, one of %v", bible.VersificationNames()))
+	flags.StringVar(&entry.Canon, "canon", "", fmt.Sprintf("canon of its books, one of %v", bible.CanonNames))
+	flags.StringVar(&entry.Format, "format", "openbible", "text format")
+	flags.StringVar(&entry.Checksum, "checksum", "", "sha256: and the hex SHA-256 of the text")
+	flags.Parse(args)
+	if entry.Code == "" || entry.Title == "" || entry.URL == "" {
+		return fmt.Errorf("catalog add needs -code, -title and -url")
+	}
+	if entry.Versification != "" {
+		if _, ok := bible.LookupVersification(entry.Versification); !ok {
+			return fmt.Errorf("unknown versification %q, choose one of %v", entry.Versification, bible.VersificationNames())
+		}
+	}
+
+	path, err := userCatalogPath()
+	if err != nil {
+		return err
+	}
+	catalog, err := bible.LoadCatalog(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		catalog, err = &bible.Catalog{Version: 1}, nil
+	}
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for i := range catalog.Entries {
+		if strings.EqualFold(catalog.Entries[i].Code, entry.Code) {
+			catalog.Entries[i], replaced = entry, true
+		}
+	}
+	if !replaced {
+		catalog.Entries = append(catalog.Entries, entry)
+	}
+	data, err := json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("Added %s to %s\n", entry.Code, path)
+	return nil
+}
@@ -0,0 +1,634 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	"github.com/botanyhelp/goBibleVerseComparer/bible"
+)
+
+// errDone is returned by readLine when a command typed at a prompt showed
+// a passage, which ends the reference being asked for
+var errDone = errors.New("done")
+
+// searchLimit is how many verses the search command shows
+const searchLimit int = 50
+
+// replCommand is a command that may be typed in place of a reference, at
+// any of the prompts and in batch mode
+type replCommand struct {
+	name    string
+	aliases []string
+	// usage shows its arguments, like "<codes>"
+	usage   string
+	summary string
+	// help says more about it, for 'help <command>'
+	help string
+	// run does the command with the rest of the line.  It returns true
+	// when it showed a passage, which ends the reference being asked for.
+	run func(r *repl, args string) (bool, error)
+	// complete, when not nil, returns the completions of the last
+	// argument typed so far, each the whole of args completed
+	complete func(r *repl, args string) []string
+	// subcommands, when not nil, are the words that must follow the
+	// command for the line to be taken for it, so that a book with the
+	// same name, like Mark, can still be typed in lowercase
+	subcommands []string
+}
+
+// replCommands are the commands, in the order help lists them.  They are
+// set in init because the help command lists them.
+var replCommands []replCommand
+
+func init() {
+	replCommands = []replCommand{
+		{name: "help", usage: "[command]", summary: "explain the prompts, or one command",
+			help: "help alone explains the prompts and lists the commands, and help and a command, like 'help use', says more about that command.",
+			run:  (*repl).helpCommand, complete: completeCommandNames},
+		{name: "quit", summary: "stop the program",
+			help: "quit stops the program, as does the end of the input, like Ctrl+D.",
+			run:  func(r *repl, args string) (bool, error) { return false, errQuit }},
+		{name: "next", aliases: []string{"n"}, summary: "show the verse after the last one shown",
+			help: "next, or n, shows the verse after the last verse shown, going on into the next chapter and book.",
+			run:  navigationCommand("next")},
+		{name: "prev", aliases: []string{"p", "previous"}, summary: "show the verse before the first one shown",
+			help: "prev, or p, shows the verse before the first verse shown, going back into the chapter and book before.",
+			run:  navigationCommand("prev")},
+		{name: "nc", summary: "show the chapter after the last one shown",
+			help: "nc shows the whole of the chapter after the last chapter shown.",
+			run:  navigationCommand("nextChapter")},
+		{name: "pc", summary: "show the chapter before the first one shown",
+			help: "pc shows the whole of the chapter before the first chapter shown.",
+			run:  navigationCommand("prevChapter")},
+		{name: "use", usage: "<codes>", summary: "show only these translations, in this order",
+			help: "use and the codes of translations, like 'use asv,kjv', shows just those translations from now on, in that order.  Translations that are not loaded yet are loaded from the catalog.",
+			run:  (*repl).useCommand, complete: completeTranslationCodes},
+		{name: "add", usage: "<codes>", summary: "show these translations as well",
+			help: "add and the codes of translations, like 'add web', shows them after the ones shown already, loading them from the catalog when they are not loaded yet.",
+			run:  (*repl).addCommand, complete: completeTranslationCodes},
+		{name: "drop", usage: "<codes>", summary: "stop showing these translations",
+			help: "drop and the codes of translations, like 'drop kjv', stops showing them.  They stay loaded, so 'add kjv' brings them back at once.",
+			run:  (*repl).dropCommand, complete: completeTranslationCodes},
+		{name: "books", summary: "list the books that can be looked up",
+			help: "books lists the books of the canon that at least one translation shown has, in canonical order.",
+			run:  (*repl).booksCommand},
+		{name: "chapters", usage: "<book>", summary: "list the chapters of a book",
+			help: "chapters and a book, like 'chapters Ps', lists the chapters of that book the translations shown have.",
+			run:  (*repl).chaptersCommand, complete: completeBookNames},
+		{name: "info", usage: "[code]", summary: "describe the loaded translations, or one of them",
+			help: "info alone lists every loaded translation, with a * by the ones shown.  info and a code, like 'info kjv', describes that translation: its title, verse numbering and how many books, chapters and verses it has.",
+			run:  (*repl).infoCommand, complete: completeTranslationCodes},
+		{name: "search", usage: "<terms>", summary: "find the verses with every term",
+			help: fmt.Sprintf("search and some words, like 'search living water', lists the verses of the translations shown that have every word, ignoring case.  Words in double quotes, like 'search \"living water\"', must come together.  The first %d verses found are listed.", searchLimit),
+			run:  (*repl).searchCommand},
+		{name: "diff", usage: "[a b] [reference]", summary: "compare two translations word by word",
+			help: "diff compares two translations word by word, marking [-words only the first has-] and {+words only the second has+}.  Without codes it compares the first two translations shown, and without a reference the passage shown last, so 'diff', 'diff kjv asv' and 'diff kjv asv John 3:16' all work.",
+			run:  (*repl).diffCommand, complete: completeTranslationCodes},
+		{name: "export", usage: "<file> [reference]", summary: "save a passage to a file",
+			help: "export and a file name, like 'export sermon.txt', saves the passage shown last to that file as it was shown, without stopping for each screenful.  A reference after the file name, like 'export sermon.txt Matt 5:3-12', saves that passage instead.",
+			run:  (*repl).exportCommand},
+		{name: "mark", usage: "add|list|go|tag|note|rm ...", summary: "bookmark references, and list and show them",
+			help: "mark add, and a reference, some #tags and a note, like 'mark add John 3:16 #love read at weddings', bookmarks the reference; without a reference it bookmarks the passage shown last.  mark list lists the bookmarks, numbered, or with a tag, like 'mark list #love', only those with it.  mark go and a number, like 'mark go 2', shows that bookmark in the translations shown, and mark go and a tag shows every bookmark with it.  mark tag and a number adds #tags, mark note and a number replaces the note, and mark rm and a number removes the bookmark.  mark alone, or followed by a chapter, is the book of Mark.",
+			run:  (*repl).markCommand, complete: completeMarkCommands, subcommands: markCommands},
+		{name: "note", usage: "add|list|search|rm|export ...", summary: "write notes on verses, and list, search and export them",
+			help: "note add, and a reference, some #tags and the text, like 'note add John 3:16 #love read at weddings', writes a note on those verses; without a reference it is on the passage shown last.  Notes are shown under their verses in every translation.  note list lists the notes, numbered, or only those on a reference, like 'note list John 3', or with a tag.  note search and some words lists the notes that have them, note rm and a number removes a note, and note export, a file name and any tags, like 'note export notes.md #love', saves the notes to a Markdown file with the text of the first translation shown.",
+			run:  (*repl).noteCommand, complete: completeNoteCommands},
+		{name: "plan", usage: "[today|day|done|undo|start|status|list|stop ...]", summary: "follow a reading plan, showing each day's passages",
+			help: fmt.Sprintf("plan list lists the built-in reading plans, %v, and plan start and a plan, or a JSON file like {\"title\": \"Gospels\", \"days\": [[\"Matt 1-3\"], [\"Matt 4-6\"]]}, starts following it today, or from a date after it, like 'plan start year 2026-01-01'.  plan alone, or plan today, shows today's passages in the translations shown, and plan day and a number shows that day's.  plan done marks today, or the day numbered after it, read, and plan undo unmarks it.  plan status says how far through the plan you are and which days are not read yet, and plan stop stops following it.", bible.PlanNames),
+			run:  (*repl).planCommand, complete: completePlanCommands},
+		{name: "votd", usage: "[2026-12-25]", summary: "show the verse of the day",
+			help: "votd shows the verse of the day across the translations shown, chosen from a list of well-known verses by the date, so it is the same verse all day and on every computer; with a date after it, like 'votd 2026-12-25', it shows that day's.",
+			run:  (*repl).votdCommand},
+		{name: "random", usage: "[any] [ot|nt|book]", summary: "show a verse picked at random",
+			help: "random shows a verse picked at random from the list of well-known verses the verse of the day comes from, and random any from every verse.  After them ot or nt picks only from the Old or New Testament, and a book, like 'random any Psalm', only from that book; a book with none of the well-known verses is picked from all its verses.",
+			run:  (*repl).randomCommand, complete: completeRandomBooks},
+		{name: "xref", usage: "[show] [John 3:16] [n] | import file", summary: "list or show the passages related to a verse",
+			help: fmt.Sprintf("xref import and a file, like 'xref import cross_references.txt', imports the cross references OpenBible.info publishes, which readers have voted on.  xref and a reference, like 'xref John 3:16', lists the passages related to it, the most voted for first, and xref alone those related to the passage shown last; a number after the reference lists that many, %d by default.  xref show shows the passages themselves in the translations shown, %d by default.", xrefsListed, xrefsShown),
+			run:  (*repl).xrefCommand, complete: completeXrefCommands},
+		{name: "set", usage: "[setting value]", summary: "change a setting, or list them",
+			help: fmt.Sprintf("set alone lists the settings, and set and a setting and a value changes it, like 'set width 100'.  The settings are width, which passages are wrapped to; height, the lines shown before asking for more, 0 for no stopping; layout, one of %v; versification, the verse numbering references are typed in, one of %v; and format, how verses are written, one of %v.", layouts, bible.VersificationNames(), formats),
+			run:  (*repl).setCommand, complete: completeSettings},
+	}
+}
+
+// synopsis is how the command is typed, with its aliases, like "next, n"
+func (c *replCommand) synopsis() string {
+	return strings.TrimSpace(strings.Join(append([]string{c.name}, c.aliases...), ", ") + " " + c.usage)
+}
+
+// findCommand returns the command with name, or one of its aliases, or nil
+func findCommand(name string) *replCommand {
+	for i := range replCommands {
+		if replCommands[i].name == name || slices.Contains(replCommands[i].aliases, name) {
+			return &replCommands[i]
+		}
+	}
+	return nil
+}
+
+// commandWords are the names and aliases of every command
+func commandWords() []string {
+	var words []string
+	for _, command := range replCommands {
+		words = append(append(words, command.name), command.aliases...)
+	}
+	return words
+}
+
+// splitCommand splits line into the command it starts with and the rest
+// of it.  Commands are lowercase, and those named like a book need one of
+// their subcommands after them, so books like Mark are never taken for one.
+func splitCommand(line string) (*replCommand, string) {
+	name, args, _ := strings.Cut(strings.TrimSpace(line), " ")
+	args = strings.TrimSpace(args)
+	command := findCommand(name)
+	if command != nil && command.subcommands != nil {
+		subcommand, _, _ := strings.Cut(args, " ")
+		if !slices.Contains(command.subcommands, subcommand) {
+			return nil, ""
+		}
+	}
+	return command, args
+}
+
+// completeCommand completes the arguments of a command being typed,
+// like the codes after 'use'.  It reports false when before does not yet
+// have a command and a space.
+func (r *repl) completeCommand(before string) ([]string, bool) {
+	trimmed := strings.TrimLeft(before, " ")
+	name, args, found := strings.Cut(trimmed, " ")
+	command := findCommand(name)
+	if !found || command == nil {
+		return nil, false
+	}
+	if command.subcommands != nil {
+		// until a subcommand is typed, the line may be a reference to a book like Mark
+		subcommand, _, more := strings.Cut(strings.TrimLeft(args, " "), " ")
+		if (more && !slices.Contains(command.subcommands, subcommand)) || (!more && len(completeWords(subcommand, command.subcommands)) == 0) {
+			return nil, false
+		}
+	}
+	if command.complete == nil {
+		return nil, true
+	}
+	typed := before[:len(before)-len(args)]
+	var completions []string
+	for _, completion := range command.complete(r, args) {
+		completions = append(completions, typed+completion)
+	}
+	return completions, true
+}
+
+// completeLastWord completes the last word of args, separated by spaces or
+// commas, from words
+func completeLastWord(args string, words []string) []string {
+	start := strings.LastIndexAny(args, " ,") + 1
+	var completions []string
+	for _, word := range completeWords(args[start:], words) {
+		completions = append(completions, args[:start]+word)
+	}
+	return completions
+}
+
+func completeCommandNames(r *repl, args string) []string {
+	return completeLastWord(args, commandWords())
+}
+
+// completeTranslationCodes completes the codes of the loaded translations
+// and, once it has been read, of the catalog
+func completeTranslationCodes(r *repl, args string) []string {
+	var codes []string
+	for _, translation := range r.loaded {
+		codes = append(codes, translation.Code)
+	}
+	if r.catalog != nil {
+		for _, entry := range r.catalog.Entries {
+			if !slices.Contains(codes, entry.Code) {
+				codes = append(codes, entry.Code)
+			}
+		}
+	}
+	return completeLastWord(args, codes)
+}
+
+func completeBookNames(r *repl, args string) []string {
+	return completeWords(args, r.books)
+}
+
+func completeSettings(r *repl, args string) []string {
+	setting, _, found := strings.Cut(args, " ")
+	if !found {
+		return completeWords(args, []string{"width", "height", "layout", "versification", "format"})
+	}
+	switch setting {
+	case "layout":
+		return completeLastWord(args, layouts)
+	case "versification":
+		return completeLastWord(args, bible.VersificationNames())
+	case "format":
+		return completeLastWord(args, formats)
+	}
+	return nil
+}
+
+// navigationCommand returns the run function of a navigation command,
+// which shows the passage navigate gives
+func navigationCommand(direction string) func(r *repl, args string) (bool, error) {
+	return func(r *repl, args string) (bool, error) {
+		passage, err := navigate(direction, r.lastShown, r.books, r.ropes)
+		if err != nil {
+			return false, err
+		}
+		r.showPassage(passage)
+		return true, nil
+	}
+}
+
+func (r *repl) helpCommand(args string) (bool, error) {
+	if args == "" {
+		promptHelp := r.promptHelp
+		if promptHelp == "" {
+			promptHelp = bookPromptHelp()
+		}
+		writeHelp(r.out, promptHelp, r.width)
+		return false, nil
+	}
+	command := findCommand(args)
+	if command == nil {
+		return false, fmt.Errorf("there is no %s command, type 'help' for the list", args)
+	}
+	fmt.Fprintf(r.out, "%s\n", command.synopsis())
+	for _, line := range wrapText(command.help, r.width, "  ") {
+		fmt.Fprintln(r.out, line)
+	}
+	return false, nil
+}
+
+// splitCodes splits a list of translation codes like "asv,kjv" or "asv kjv"
+func splitCodes(args string) []string {
+	return strings.FieldsFunc(args, func(c rune) bool { return c == ',' || unicode.IsSpace(c) })
+}
+
+// findTranslations returns the translations with codes, loading the ones
+// that are not loaded yet from the catalog
+func (r *repl) findTranslations(codes []string) ([]*bible.Translation, error) {
+	if len(codes) == 0 {
+		return nil, fmt.Errorf("give the codes of some translations, like kjv,asv; 'info' lists the loaded ones")
+	}
+	var found []*bible.Translation
+	for _, code := range codes {
+		translation := bible.FindTranslation(r.loaded, code)
+		if translation == nil {
+			var err error
+			if translation, err = r.loadTranslation(code); err != nil {
+				return nil, err
+			}
+		}
+		if !slices.Contains(found, translation) {
+			found = append(found, translation)
+		}
+	}
+	return found, nil
+}
+
+// loadTranslation loads the translation with code from the catalog, which
+// is read the first time it is needed
+func (r *repl) loadTranslation(code string) (*bible.Translation, error) {
+	if r.catalog == nil {
+		catalog, err := loadCatalogs(r.catalogs)
+		if err != nil {
+			return nil, err
+		}
+		r.catalog = catalog
+	}
+	entry, ok := r.catalog.Find(code)
+	if !ok {
+		return nil, fmt.Errorf("%q is neither loaded nor in the catalog, see 'catalog list' for the codes", code)
+	}
+	fmt.Fprintf(r.out, "Loading %s\n", entry.Title)
+	translation, _, err := loadCatalogEntry(entry, r.cacheDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", entry.Title, err)
+	}
+	r.loaded = append(r.loaded, translation)
+	return translation, nil
+}
+
+// setTranslations shows translations from now on, which changes the books,
+// chapters and verses that can be looked up
+func (r *repl) setTranslations(translations []*bible.Translation) {
+	r.translations = translations
+	r.ropes = schemeRopes(translations, r.scheme)
+	r.books = bible.ValidBooksFor(r.canonBooks, bible.Ropes(translations))
+	var titles []string
+	for _, translation := range translations {
+		titles = append(titles, translation.Title)
+	}
+	fmt.Fprintf(r.out, "Showing %s\n", strings.Join(titles, ", "))
+}
+
+func (r *repl) useCommand(args string) (bool, error) {
+	translations, err := r.findTranslations(splitCodes(args))
+	if err != nil {
+		return false, err
+	}
+	r.setTranslations(translations)
+	return false, nil
+}
+
+func (r *repl) addCommand(args string) (bool, error) {
+	added, err := r.findTranslations(splitCodes(args))
+	if err != nil {
+		return false, err
+	}
+	translations := slices.Clone(r.translations)
+	for _, translation := range added {
+		if !slices.Contains(translations, translation) {
+			translations = append(translations, translation)
+		}
+	}
+	r.setTranslations(translations)
+	return false, nil
+}
+
+func (r *repl) dropCommand(args string) (bool, error) {
+	codes := splitCodes(args)
+	if len(codes) == 0 {
+		return false, fmt.Errorf("give the codes of the translations to drop, like 'drop kjv'")
+	}
+	translations := slices.Clone(r.translations)
+	for _, code := range codes {
+		translation := bible.FindTranslation(translations, code)
+		if translation == nil {
+			return false, fmt.Errorf("%s is not shown, so it cannot be dropped", code)
+		}
+		translations = slices.DeleteFunc(translations, func(t *bible.Translation) bool { return t == translation })
+	}
+	if len(translations) == 0 {
+		return false, fmt.Errorf("at least one translation has to be shown")
+	}
+	r.setTranslations(translations)
+	return false, nil
+}
+
+func (r *repl) booksCommand(args string) (bool, error) {
+	writeBooks(r.out, r.books, r.width)
+	return false, nil
+}
+
+func (r *repl) chaptersCommand(args string) (bool, error) {
+	if args == "" {
+		return false, fmt.Errorf("give a book, like 'chapters Genesis'")
+	}
+	book, err := bible.ResolveBookName(args, r.books)
+	if err != nil {
+		return false, err
+	}
+	fmt.Fprintf(r.out, "%s: %s\n", book, bible.FormatNumberRanges(bible.ChaptersOf(r.ropes, book)))
+	return false, nil
+}
+
+func (r *repl) infoCommand(args string) (bool, error) {
+	if args == "" {
+		w := tabwriter.NewWriter(r.out, 0, 4, 2, ' ', 0)
+		for _, translation := range r.loaded {
+			shown := " "
+			if slices.Contains(r.translations, translation) {
+				shown = "*"
+			}
+			fmt.Fprintf(w, "%s %s\t%s\t%s numbering\n", shown, translation.Code, translation.Title, translation.Versification.Name)
+		}
+		return false, w.Flush()
+	}
+	translation := bible.FindTranslation(r.loaded, args)
+	if translation == nil {
+		return false, fmt.Errorf("%s is not loaded, 'info' lists the loaded translations", args)
+	}
+	chapters, verses := 0, 0
+	for _, book := range translation.Rope.Books {
+		chapters += len(translation.Rope.Segments[book])
+		for _, chapterVerses := range translation.Rope.Segments[book] {
+			verses += len(chapterVerses)
+		}
+	}
+	w := tabwriter.NewWriter(r.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "Code:\t%s\n", translation.Code)
+	fmt.Fprintf(w, "Title:\t%s\n", translation.Title)
+	fmt.Fprintf(w, "Numbering:\t%s\n", translation.Versification.Name)
+	fmt.Fprintf(w, "Books:\t%d\n", len(translation.Rope.Books))
+	fmt.Fprintf(w, "Chapters:\t%d\n", chapters)
+	fmt.Fprintf(w, "Verses:\t%d\n", verses)
+	fmt.Fprintf(w, "Shown:\t%t\n", slices.Contains(r.translations, translation))
+	return false, w.Flush()
+}
+
+func (r *repl) searchCommand(args string) (bool, error) {
+	if len(bible.SearchTerms(args)) == 0 {
+		return false, fmt.Errorf("give some words to search for, like 'search living water'")
+	}
+	hits := bible.SearchVerses(r.translations, args, r.books, searchLimit+1)
+	if r.format != "text" {
+		return false, r.resultsFor(r.out).write(searchResults(hits[:min(len(hits), searchLimit)]))
+	}
+	if len(hits) == 0 {
+		fmt.Fprintf(r.out, "No verses have %s\n", args)
+		return false, nil
+	}
+	var lines []string
+	if len(hits) > searchLimit {
+		hits = hits[:searchLimit]
+		lines = append(lines, fmt.Sprintf("The first %d verses that have %s:", searchLimit, args))
+	} else {
+		lines = append(lines, fmt.Sprintf("%d verses have %s:", len(hits), args))
+	}
+	for _, hit := range hits {
+		label := fmt.Sprintf("%s (%s)", hit.Ref, hit.Translation.Code)
+		words := strings.Fields(hit.Text)
+		lines = append(lines, wrapWords(words, words, r.width, label+" ", r.style.label(label)+" ")...)
+	}
+	pageLines(r.out, lines, r.height, r.in)
+	return false, nil
+}
+
+// passageArg parses the reference typed after a command, or gives the
+// passage shown last when there is none
+func (r *repl) passageArg(reference string) (bible.Passage, error) {
+	if reference == "" {
+		if r.lastShown.Book == "" {
+			return bible.Passage{}, fmt.Errorf("nothing has been shown yet, so give a reference, like John 3:16")
+		}
+		return r.lastShown, nil
+	}
+	passage, err := bible.ParseReference(reference, r.books)
+	if err != nil {
+		return bible.Passage{}, err
+	}
+	if passage.StartChapter == 0 {
+		return bible.Passage{}, fmt.Errorf("%s needs a chapter, like '%s 1'", reference, passage.Book)
+	}
+	return passage, nil
+}
+
+// leadingPassage parses the reference words start with, the longest run
+// of them that is a reference with a chapter, and returns it and the words
+// after it.  When words do not start with one, it returns the passage
+// shown last and all of words.
+func (r *repl) leadingPassage(words []string) (bible.Passage, []string, error) {
+	for end := len(words); end > 0; end-- {
+		passage, err := bible.ParseReference(strings.Join(words[:end], " "), r.books)
+		if err == nil && passage.StartChapter != 0 {
+			return passage, words[end:], nil
+		}
+	}
+	passage, err := r.passageArg("")
+	return passage, words, err
+}
+
+func (r *repl) diffCommand(args string) (bool, error) {
+	fields := strings.Fields(args)
+	var pair []*bible.Translation
+	for len(fields) > 0 && len(pair) < 2 {
+		translation := bible.FindTranslation(r.loaded, fields[0])
+		if translation == nil {
+			break
+		}
+		pair, fields = append(pair, translation), fields[1:]
+	}
+	for _, translation := range r.translations {
+		if len(pair) < 2 && !slices.Contains(pair, translation) {
+			pair = append(pair, translation)
+		}
+	}
+	if len(pair) < 2 {
+		return false, fmt.Errorf("diff needs two translations, like 'diff kjv asv John 3:16'")
+	}
+	passage, err := r.passageArg(strings.Join(fields, " "))
+	if err != nil {
+		return false, err
+	}
+	refs := passage.VerseRefs(r.ropes)
+	if len(refs) == 0 {
+		return false, fmt.Errorf("none of the translations shown have %s", passage)
+	}
+	lines := []string{fmt.Sprintf("%s: %s %s", r.style.heading(passage.String()), r.style.removed("[-"+pair[0].Title+"-]"), r.style.added("{+"+pair[1].Title+"+}"))}
+	for _, ref := range refs {
+		aText, _, aFound := pair[0].LookupVerse(ref, r.scheme)
+		bText, _, bFound := pair[1].LookupVerse(ref, r.scheme)
+		var ops []bible.DiffOp
+		if aFound && bFound {
+			ops = bible.DiffWords(aText, bText)
+		} else {
+			// a verse one of them lacks is not a diff against nothing, so
+			// each side is shown whole, as printVerse shows it
+			const omitted = "[omitted in this translation]"
+			if !aFound {
+				aText = omitted
+			}
+			if !bFound {
+				bText = omitted
+			}
+			ops = []bible.DiffOp{{Op: "delete", Text: aText}, {Op: "insert", Text: bText}}
+		}
+		// the markers are kept with color, so the words can be told apart without it
+		var words, styled []string
+		for _, op := range ops {
+			opWords := strings.Fields(op.Text)
+			switch op.Op {
+			case "delete":
+				opWords[0], opWords[len(opWords)-1] = "[-"+opWords[0], opWords[len(opWords)-1]+"-]"
+			case "insert":
+				opWords[0], opWords[len(opWords)-1] = "{+"+opWords[0], opWords[len(opWords)-1]+"+}"
+			}
+			for _, word := range opWords {
+				words = append(words, word)
+				switch op.Op {
+				case "delete":
+					styled = append(styled, r.style.removed(word))
+				case "insert":
+					styled = append(styled, r.style.added(word))
+				default:
+					styled = append(styled, word)
+				}
+			}
+		}
+		number := fmt.Sprintf("%d:%d ", ref.Chapter, ref.Verse)
+		lines = append(lines, wrapWords(words, styled, r.width, number, number)...)
+	}
+	pageLines(r.out, lines, r.height, r.in)
+	return false, nil
+}
+
+func (r *repl) exportCommand(args string) (bool, error) {
+	path, reference, _ := strings.Cut(args, " ")
+	if path == "" {
+		return false, fmt.Errorf("give a file to export to, like 'export sermon.txt'")
+	}
+	passage, err := r.passageArg(strings.TrimSpace(reference))
+	if err != nil {
+		return false, err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return false, err
+	}
+	err = r.writePassage(file, passage, 0)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return false, err
+	}
+	fmt.Fprintf(r.out, "Saved %s to %s\n", passage, path)
+	return false, nil
+}
+
+func (r *repl) setCommand(args string) (bool, error) {
+	fields := strings.Fields(args)
+	if len(fields) == 0 {
+		fmt.Fprintf(r.out, "width %d\nheight %d\nlayout %s\nversification %s\nformat %s\n", r.width, r.height, r.layout, r.scheme.Name, r.format)
+		return false, nil
+	}
+	if len(fields) != 2 {
+		return false, fmt.Errorf("give a setting and a value, like 'set width 100'")
+	}
+	setting, value := fields[0], fields[1]
+	switch setting {
+	case "width", "height":
+		number, err := strconv.Atoi(value)
+		if err != nil || number < 0 || (setting == "width" && number < 20) {
+			return false, fmt.Errorf("%s must be a number, at least 20 for the width and 0 or more for the height", setting)
+		}
+		if setting == "width" {
+			r.width = number
+		} else {
+			r.height = number
+		}
+	case "layout":
+		if !slices.Contains(layouts, value) {
+			return false, fmt.Errorf("unknown layout %q, choose one of %v", value, layouts)
+		}
+		r.layout = value
+	case "versification":
+		scheme, ok := bible.LookupVersification(value)
+		if !ok {
+			return false, fmt.Errorf("unknown versification %q, choose one of %v", value, bible.VersificationNames())
+		}
+		r.scheme = scheme
+		r.ropes = schemeRopes(r.translations, scheme)
+	case "format":
+		if !slices.Contains(formats, value) {
+			return false, fmt.Errorf("unknown format %q, choose one of %v", value, formats)
+		}
+		r.format, r.results = value, nil
+	default:
+		return false, fmt.Errorf("unknown setting %q, the settings are width, height, layout, versification and format", setting)
+	}
+	fmt.Fprintf(r.out, "%s is now %s\n", setting, value)
+	return false, nil
+}
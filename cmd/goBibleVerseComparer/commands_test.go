@@ -0,0 +1,230 @@
+package main
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/botanyhelp/goBibleVerseComparer/bible"
+)
+
+func TestReplCommands(t *testing.T) {
+	exportPath := filepath.Join(t.TempDir(), "export.txt")
+	notesExportPath := filepath.Join(t.TempDir(), "notes.md")
+	today := time.Date(2026, 3, 3, 20, 0, 0, 0, time.Local)
+	xrefFile := filepath.Join(t.TempDir(), "cross_references.txt")
+	if err := os.WriteFile(xrefFile, []byte("From Verse\tTo Verse\tVotes\n"+
+		"John.3.16\tGen.1.1\t5\n"+
+		"John.3.16\tPs.23.1-Ps.23.2\t10\n"+
+		"John.3.16\t3John.1.14\t1\n"+
+		"Ps.51.1\tMal.4.5\t7\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	planFile := filepath.Join(t.TempDir(), "plan.json")
+	if err := os.WriteFile(planFile, []byte(`{"title": "A short plan", "days": [["Gen 1"], ["Ps 23"], ["John 3:16", "3 John 1:2"]]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// the curated verses the fixtures have, which votd picks from
+	fixtureCurated := []bible.VerseRef{
+		{Book: "Genesis", Chapter: 1, Verse: 1}, {Book: "Psalm", Chapter: 23, Verse: 1},
+		{Book: "Malachi", Chapter: 4, Verse: 6}, {Book: "John", Chapter: 3, Verse: 16},
+	}
+	tests := []struct {
+		name, input string
+		want        []string
+		notWant     []string
+	}{
+		{"use", "use kjv\nJohn 3:16\n", []string{"Showing King James Bible\n", "John 3:16\n  King James Bible: For God so loved"}, []string{"Douay-Rheims Bible"}},
+		{"use in order", "use drb, kjv\ninfo\n", []string{"Showing Douay-Rheims Bible, King James Bible", "* kjv", "  jps"}, nil},
+		{"drop", "drop jps drb\nJohn 3:16\n", []string{"Showing King James Bible\n"}, []string{"Douay-Rheims Bible"}},
+		{"drop them all", "drop kjv,jps,drb\n", []string{"at least one translation has to be shown"}, nil},
+		{"drop one not shown", "use kjv\ndrop drb\n", []string{"drb is not shown"}, nil},
+		{"add from the catalog", "use kjv\nadd web\nJohn 3:16\n", []string{"Loading World English Bible\n", "Showing King James Bible, World English Bible", "  World English Bible: For God so loved"}, nil},
+		{"add one loaded", "use kjv\nadd drb\n", []string{"Showing King James Bible, Douay-Rheims Bible"}, []string{"Loading"}},
+		{"use one nowhere", "use xyz\n", []string{`"xyz" is neither loaded nor in the catalog`}, nil},
+		{"search", "use kjv\nsearch light\n", []string{"1 verses have light:\nGenesis 1:3 (kjv) And God said, Let there be light"}, nil},
+		{"search nothing found", "search unicorn\n", []string{"No verses have unicorn"}, nil},
+		{"diff", "diff kjv drb John 3:16\n", []string{"John 3:16: [-King James Bible-] {+Douay-Rheims Bible+}\n3:16 For God so loved the world, [-that he gave-] {+as to give+}"}, nil},
+		{"diff a verse one lacks", "diff kjv jps John 3:16\n", []string{"3:16 [-For God so loved the world,", "{+[omitted in this translation]+}"}, nil},
+		{"diff the last shown", "John 3:16\nuse kjv,drb\ndiff\n", []string{"John 3:16: [-King James Bible-] {+Douay-Rheims Bible+}"}, nil},
+		{"diff with nothing shown", "diff\n", []string{"nothing has been shown yet"}, nil},
+		{"diff one translation", "use kjv\ndiff John 3:16\n", []string{"diff needs two translations"}, nil},
+		{"export", "export " + exportPath + " Gen 1:1-2\n", []string{"Saved Genesis 1:1-2 to " + exportPath}, nil},
+		{"books", "books\n", []string{"Old Testament:\n  Genesis  Psalm    Malachi\nNew Testament:\n  John    3 John\n"}, nil},
+		{"chapters", "chapters Ps\n", []string{"Psalm: 23, 51"}, nil},
+		{"info", "info kjv\n", []string{"Title:      King James Bible\n", "Numbering:  kjv\n", "Verses:     10\n"}, nil},
+		{"mark add and list", "mark add John 3:16 #Love read at weddings\nmark list\n", []string{"Bookmarked John 3:16 as 1\n", "1  John 3:16  ", "  #love  read at weddings\n"}, nil},
+		{"mark the passage shown", "Gen 1:1\nmark add #creation\nmark add Gen 1:1 in the beginning\nmark list #creation\n", []string{"Bookmarked Genesis 1:1 as 1", "Genesis 1:1 is bookmarked already, as 1", "#creation  in the beginning"}, nil},
+		{"mark go", "mark add Gen 1:1-2 #creation\nmark add Ps 23:1\nmark go 1\nmark go #creation\n", []string{"#creation\nGenesis 1:1-2\n\n1:1\n  King James Bible:   In the beginning"}, []string{"Psalm 23:1\n"}},
+		{"mark tag, note and rm", "mark add Ps 23:1\nmark tag 1 #psalm\nmark note 1 the shepherd\nmark list\nmark rm 1\nmark list\n", []string{"Psalm 23:1 is tagged #psalm", "#psalm  the shepherd", "Removed the bookmark of Psalm 23:1", "There are no bookmarks yet"}, nil},
+		{"mark a bookmark not there", "mark go 3\n", []string{`"3" is not the number of a bookmark`}, nil},
+		{"note add and show", "note add John 3:16 #love read at weddings\nJohn 3:16\n", []string{"Noted John 3:16 as 1\n", "have life everlasting.\n  Note on John 3:16: read at weddings #love\n"}, nil},
+		{"note on a passage", "Gen 1:1-2\nnote add the creation\nGen 1\n", []string{"Noted Genesis 1:1-2 as 1", "earth.\n  Note on Genesis 1:1-2: the creation\n\n1:2"}, nil},
+		{"note in another numbering", "note add Ps 51:1 mercy\nset versification mt\nPs 51:3\n", []string{"Psalm 51:3\n", "  Note on Psalm 51:1: mercy\n"}, nil},
+		{"note list, search and rm", "note add Gen 1:1 beginning\nnote add Ps 23:1 #psalm shepherd\nnote list Ps 23\nnote search SHEPHERD\nnote rm 1\nnote list\n", []string{"2  Psalm 23:1  ", "Removed the note on Genesis 1:1", "1  Psalm 23:1  "}, []string{"1  Genesis 1:1"}},
+		{"note export", "note add Ps 23:1-2 #psalm the shepherd\nnote add Gen 1:1 beginning\nuse kjv\nnote export " + notesExportPath + "\n", []string{"Saved 2 notes to " + notesExportPath}, nil},
+		{"plan list", "plan list\n", []string{"year           365 days  The whole Bible in a year\n", "nt90           90 days   The New Testament in 90 days\n"}, nil},
+		{"plan today", "plan start " + planFile + " 2026-03-02\nplan\n", []string{"Started A short plan on 2026-03-02", "Day 2 of 3: Psalm 23\n\nPsalm 23\n\n23:1\n", "'plan done 2' marks this day read"}, nil},
+		{"plan day", "plan start " + planFile + "\nplan day 3\n", []string{"Day 3 of 3: John 3:16; 3 John 1:2\n", "John 3:16\n", "3 John 1:2\n"}, nil},
+		{"plan done and status", "plan start " + planFile + " 2026-03-01\nplan done 1\nplan status\nplan day 1\nplan undo 1\nplan status\n", []string{"Day 1 is read, 1 of 3 days", "Today is day 3 of 3; 1 days are read\nDays not read yet: 2\n", "Day 1 of 3, read: Genesis 1", "Days not read yet: 1–2\n"}, nil},
+		{"plan not started yet", "plan start year 2026-04-01\nplan\nplan status\n", []string{"The whole Bible in a year starts on 2026-04-01", "It starts in 29 days, and has 365 days"}, nil},
+		{"plan over", "plan start " + planFile + " 2026-01-01\nplan\nplan stop\nplan\n", []string{"A short plan is over, with 0 of 3 days read", "Stopped A short plan", "no reading plan has been started"}, nil},
+		{"plan a day not there", "plan start nt90\nplan day 91\n", []string{"day 91 is not in The New Testament in 90 days"}, nil},
+		{"plan unknown", "plan start weekly\n", []string{`there is no reading plan "weekly"`}, nil},
+		{"votd", "votd\n", []string{"Verse of the day, Tuesday 3 March 2026\n" + bible.VerseOfTheDay(today, fixtureCurated).String() + "\n"}, nil},
+		{"votd of a date", "votd 2026-12-25\n", []string{"Verse of the day, Friday 25 December 2026\n" + bible.VerseOfTheDay(time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC), fixtureCurated).String() + "\n"}, nil},
+		{"random from a book", "random Mal\nrandom nt\n", []string{"Malachi 4:6\n  King James Bible:   And he shall turn", "John 3:16\n"}, []string{"Malachi 4:5"}},
+		{"random any", "random any 3 John\n", []string{"3 John 1:14\n"}, nil},
+		{"random with none curated", "random 3 John\n", []string{"3 John 1:14\n"}, nil},
+		{"random in the Old Testament", "random ot\n", nil, []string{"John 3:16"}},
+		{"random in a book not loaded", "random Tobit\n", []string{"Tobit is not a book we know"}, nil},
+		{"xref", "xref import " + xrefFile + "\nxref John 3:16\n", []string{"Imported the cross references of 2 verses", "Cross references of John 3:16, most votes first:\n  Psalm 23:1-2  10 votes\n  Genesis 1:1   5 votes\n  3 John 1:14   1 vote\n"}, nil},
+		{"xref a few", "xref import " + xrefFile + "\nJohn 3:16\nxref 2\n", []string{"  Genesis 1:1   5 votes\nand 1 more; 'xref John 3:16 3' lists them all"}, []string{"3 John 1:14   1 vote"}},
+		{"xref show", "xref import " + xrefFile + "\nxref show John 3:16 1\n", []string{"Cross reference 1 of John 3:16, 10 votes\nPsalm 23:1-2\n\n23:1\n  King James Bible:   The LORD [is] my shepherd"}, []string{"Genesis 1:1"}},
+		{"xref in another numbering", "xref import " + xrefFile + "\nset versification mt\nxref Ps 51:3\n", []string{"Cross references of Psalm 51:3", "  Malachi 3:23  7 votes"}, nil},
+		{"xref none", "xref import " + xrefFile + "\nxref Gen 1:1\n", []string{"No cross references of Genesis 1:1 have been imported"}, nil},
+		{"xref not imported", "xref John 3:16\n", []string{"no cross references have been imported"}, nil},
+		{"set", "set width 100\nset\n", []string{"width is now 100", "width 100\nheight 0\nlayout interleaved"}, nil},
+		{"set format", "set format jsonl\nuse kjv\nJohn 3:16\nsearch light\n", []string{"format is now jsonl", `{"reference":"John 3:16","translation":"kjv","title":"King James Bible","text":"For God so loved`, `{"reference":"Genesis 1:3","translation":"kjv"`}, []string{"1 verses have light"}},
+		{"set a bad layout", "set layout sideways\n", []string{`unknown layout "sideways"`}, nil},
+		{"help for a command", "help use\n", []string{"use <codes>\n  use and the codes of translations"}, nil},
+		{"help at the chapter prompt", "Gen\nhelp\n1\n1\n", []string{"Type the number of a chapter of Genesis, which has chapters 1.", "These commands work at every prompt", "Genesis 1:1\n  King James Bible:   In the beginning"}, []string{"help is NOT"}},
+		{"help at the verse prompt", "Gen\n1\nhelp\n", []string{"Type the number of a verse of Genesis 1, which has verses 1–3,", "  Matt 5:3-12   a range of verses\n"}, nil},
+		{"help at the book prompt", "help\n", []string{"Type a book, like 'Genesis'", "  use <codes>    "}, nil},
+		{"next at the chapter prompt", "Gen 1:1\nPs\nn\n", []string{"Genesis 1:2\n  King James Bible:   And the earth"}, []string{"Enter the verse number"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			prompter, out := newTestRepl(t, test.input)
+			prompter.cacheDir = "off"
+			prompter.bookmarksPath = filepath.Join(t.TempDir(), "bookmarks.json")
+			prompter.notesPath = filepath.Join(t.TempDir(), "notes.json")
+			prompter.planPath = filepath.Join(t.TempDir(), "plan.json")
+			prompter.now = func() time.Time { return today }
+			prompter.rng = rand.New(rand.NewSource(1))
+			prompter.xrefPath = filepath.Join(t.TempDir(), "cross_references.tsv")
+			prompter.catalog = &bible.Catalog{Entries: []bible.CatalogEntry{
+				{Code: "web", Title: "World English Bible", URL: filepath.Join("..", "..", "bible", "testdata", "kjv.txt")},
+			}}
+			if err := prompter.run(); err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range test.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output does not have %q:\n%s", want, out)
+				}
+			}
+			for _, notWant := range test.notWant {
+				if strings.Contains(out.String(), notWant) {
+					t.Errorf("output has %q:\n%s", notWant, out)
+				}
+			}
+		})
+	}
+
+	exported, err := os.ReadFile(exportPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Genesis 1:1-2\n\n1:1\n  King James Bible:   In the beginning"; !strings.HasPrefix(string(exported), want) {
+		t.Errorf("exported %q, want it to start with %q", exported, want)
+	}
+
+	exported, err = os.ReadFile(notesExportPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the notes are in the order of the books
+	if want := "# Notes\n\n## Genesis 1:1\n\n> In the beginning God created the heaven and the earth.\n>\n> — King James Bible\n\nbeginning\n\n*"; !strings.HasPrefix(string(exported), want) {
+		t.Errorf("exported notes %q, want them to start with %q", exported, want)
+	}
+	if want := "## Psalm 23:1-2\n\n> <sup>1</sup> The LORD [is] my shepherd; I shall not want. <sup>2</sup> He maketh"; !strings.Contains(string(exported), want) {
+		t.Errorf("exported notes %q, want them to have %q", exported, want)
+	}
+}
+
+func TestCompleteCommand(t *testing.T) {
+	prompter, _ := newTestRepl(t, "")
+	tests := []struct {
+		before string
+		want   []string
+		ok     bool
+	}{
+		{"use k", []string{"use kjv"}, true},
+		{"use kjv,d", []string{"use kjv,drb"}, true},
+		{"diff kjv ", []string{"diff kjv kjv", "diff kjv jps", "diff kjv drb"}, true},
+		{"help u", []string{"help use"}, true},
+		{"set la", []string{"set layout"}, true},
+		{"set layout p", []string{"set layout parallel"}, true},
+		{"chapters Ma", []string{"chapters Malachi"}, true},
+		{"books ", nil, true},
+		{"mark l", []string{"mark list"}, true},
+		{"mark 1", nil, false},
+		{"mark 1:", nil, false},
+		{"plan st", []string{"plan start", "plan status", "plan stop"}, true},
+		{"plan start c", []string{"plan start chronological"}, true},
+		{"random a", []string{"random any"}, true},
+		{"random any m", []string{"random any Malachi"}, true},
+		{"xref s", []string{"xref show"}, true},
+		{"use", nil, false},
+		{"John 3", nil, false},
+	}
+	for _, test := range tests {
+		got, ok := prompter.completeCommand(test.before)
+		if !slices.Equal(got, test.want) || ok != test.ok {
+			t.Errorf("completeCommand(%q) = %q, %v, want %q, %v", test.before, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestPlanFromAnotherDirectory(t *testing.T) {
+	planDir, otherDir := t.TempDir(), t.TempDir()
+	if err := os.WriteFile(filepath.Join(planDir, "myplan.json"), []byte(`{"title": "My plan", "days": [["Ps 23"]]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	planPath := filepath.Join(t.TempDir(), "plan.json")
+
+	// the fixtures are found from this directory, so the prompts are made first
+	starter, _ := newTestRepl(t, "plan start myplan.json\n")
+	reader, out := newTestRepl(t, "plan\n")
+	starter.planPath, reader.planPath = planPath, planPath
+
+	t.Chdir(planDir)
+	if err := starter.run(); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(otherDir)
+	if err := reader.run(); err != nil {
+		t.Fatal(err)
+	}
+	if want := "Day 1 of 1: Psalm 23\n"; !strings.Contains(out.String(), want) {
+		t.Errorf("output does not have %q:\n%s", want, out)
+	}
+}
+
+func TestSplitCommand(t *testing.T) {
+	tests := []struct{ line, command, args string }{
+		{"use kjv, asv", "use", "kjv, asv"},
+		{"  next  ", "next", ""},
+		{"mark add John 3:16 #love", "mark", "add John 3:16 #love"},
+		// mark without one of its subcommands is the book
+		{"mark", "", ""},
+		{"mark 1:1", "", ""},
+		{"Mark 1:1", "", ""},
+		{"John 3:16", "", ""},
+	}
+	for _, test := range tests {
+		command, args := splitCommand(test.line)
+		name := ""
+		if command != nil {
+			name = command.name
+		}
+		if name != test.command || (command != nil && args != test.args) {
+			t.Errorf("splitCommand(%q) = %q, %q, want %q, %q", test.line, name, args, test.command, test.args)
+		}
+	}
+}
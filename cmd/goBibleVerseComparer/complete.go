@@ -0,0 +1,76 @@
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/botanyhelp/goBibleVerseComparer/bible"
+)
+
+// partialReference splits what has been typed of a reference into the
+// book and the chapter and verse typed so far, like "John 3:1" into John, 3 and 1
+var partialReference *regexp.Regexp = regexp.MustCompile(`^(.*?)\s+(\d*)(?::(\d*))?$`)
+
+// completeWords returns the words that start with before, ignoring case
+func completeWords(before string, words []string) []string {
+	var completions []string
+	for _, word := range words {
+		if len(word) >= len(before) && strings.EqualFold(word[:len(before)], before) {
+			completions = append(completions, word)
+		}
+	}
+	return completions
+}
+
+// completeNumbers returns the numbers whose digits start with before
+func completeNumbers(before string, numbers []int) []string {
+	var completions []string
+	for _, number := range numbers {
+		if digits := strconv.Itoa(number); strings.HasPrefix(digits, before) {
+			completions = append(completions, digits)
+		}
+	}
+	return completions
+}
+
+// completeReference completes the book prompt: the book names, then the
+// chapters of the book and then the verses of the chapter.  A completed
+// book is followed by a space and a completed chapter by a colon, ready
+// for what comes next.
+func (r *repl) completeReference(before string) []string {
+	before = strings.TrimLeft(before, " ")
+	completions := completeWords(before, r.books)
+	if len(completions) > 0 {
+		if len(completions) == 1 {
+			completions[0] += " "
+		}
+		return completions
+	}
+	matches := partialReference.FindStringSubmatch(before)
+	if matches == nil {
+		return nil
+	}
+	book, err := bible.ResolveBookName(matches[1], r.books)
+	if err != nil {
+		return nil
+	}
+	typedBook := before[:len(matches[1])] + " "
+	if !strings.Contains(before, ":") {
+		for _, chapter := range completeNumbers(matches[2], bible.ChaptersOf(r.ropes, book)) {
+			completions = append(completions, typedBook+chapter)
+		}
+		if len(completions) == 1 {
+			completions[0] += ":"
+		}
+		return completions
+	}
+	chapter, err := strconv.Atoi(matches[2])
+	if err != nil {
+		return nil
+	}
+	for _, verse := range completeNumbers(matches[3], bible.VersesOf(r.ropes, book, chapter)) {
+		completions = append(completions, typedBook+matches[2]+":"+verse)
+	}
+	return completions
+}
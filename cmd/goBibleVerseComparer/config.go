@@ -0,0 +1,241 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Config holds the settings that may come from the config file,
+// environment variables and flags.  Each later one of those overrides
+// the ones before it, and all of them override the defaults.
+type Config struct {
+	// Catalogs are the catalogs read on top of the built-in one, URLs or files
+	Catalogs []string `json:"catalogs"`
+	// Translations are the catalog codes of the bibles to load; empty
+	// means the first two in the catalog, unless Files or Dirs are given
+	Translations []string `json:"translations"`
+	// Files are local bible texts to load as well, each a path with an
+	// optional :title after it
+	Files []string `json:"files"`
+	// Dirs are directories whose .txt bible texts are all loaded as well
+	Dirs []string `json:"dirs"`
+	// CacheDir keeps downloaded texts between runs; "off" turns caching off
+	CacheDir      string `json:"cacheDir"`
+	Canon         string `json:"canon"`
+	Versification string `json:"versification"`
+	Layout        string `json:"layout"`
+	Width         int    `json:"width"`
+	// Format is how looked up verses are written, text or one of the
+	// structured formats
+	Format string `json:"format"`
+}
+
+// configKeys are the JSON names of the Config fields, in the order shown by 'config show'
+var configKeys []string = []string{"catalogs", "translations", "files", "dirs", "cacheDir", "canon", "versification", "layout", "width", "format"}
+
+// configEnv maps each config key to the environment variable that sets it.
+// Lists in environment variables are separated by commas.
+var configEnv map[string]string = map[string]string{
+	"catalogs":      "GOBIBLE_CATALOGS",
+	"translations":  "GOBIBLE_TRANSLATIONS",
+	"files":         "GOBIBLE_FILES",
+	"dirs":          "GOBIBLE_DIRS",
+	"cacheDir":      "GOBIBLE_CACHE_DIR",
+	"canon":         "GOBIBLE_CANON",
+	"versification": "GOBIBLE_VERSIFICATION",
+	"layout":        "GOBIBLE_LAYOUT",
+	"width":         "GOBIBLE_WIDTH",
+	"format":        "GOBIBLE_FORMAT",
+}
+
+// configFlags maps each config key to the command-line flag that sets it
+var configFlags map[string]string = map[string]string{
+	"catalogs":      "catalog",
+	"translations":  "translations",
+	"files":         "file",
+	"dirs":          "dir",
+	"cacheDir":      "cacheDir",
+	"canon":         "canon",
+	"versification": "versification",
+	"layout":        "layout",
+	"width":         "width",
+	"format":        "format",
+}
+
+// defaultConfig is the configuration when nothing else is set
+func defaultConfig() Config {
+	cacheDir := "off"
+	if dir, err := os.UserCacheDir(); err == nil {
+		cacheDir = filepath.Join(dir, "goBibleVerseComparer")
+	}
+	return Config{
+		CacheDir:      cacheDir,
+		Canon:         "protestant",
+		Versification: "kjv",
+		Layout:        "interleaved",
+		Format:        "text",
+	}
+}
+
+// configPath returns the config file to read: the one named by a -config
+// flag in args, else by GOBIBLE_CONFIG, else config.json in configDir
+func configPath(args []string) (string, error) {
+	for i, arg := range args {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "config" {
+			continue
+		}
+		if hasValue {
+			return value, nil
+		}
+		if i+1 < len(args) {
+			return args[i+1], nil
+		}
+	}
+	if path := os.Getenv("GOBIBLE_CONFIG"); path != "" {
+		return path, nil
+	}
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.json"), nil
+}
+
+// splitList splits a comma separated list, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// loadConfig returns the defaults overridden by the config file at path,
+// if it exists, and then by environment variables, along with where each
+// key's value came from
+func loadConfig(path string) (Config, map[string]string, error) {
+	config := defaultConfig()
+	sources := make(map[string]string)
+	for _, key := range configKeys {
+		sources[key] = "default"
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return config, sources, err
+	}
+	if err == nil {
+		// decode into a map first so we know which keys the file sets
+		var fileKeys map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fileKeys); err != nil {
+			return config, sources, fmt.Errorf("config file %s: %w", path, err)
+		}
+		if err := json.Unmarshal(data, &config); err != nil {
+			return config, sources, fmt.Errorf("config file %s: %w", path, err)
+		}
+		for key := range fileKeys {
+			if _, ok := sources[key]; !ok {
+				return config, sources, fmt.Errorf("config file %s: unknown key %q, the keys are %v", path, key, configKeys)
+			}
+			sources[key] = "file " + path
+		}
+	}
+
+	for _, key := range configKeys {
+		value, ok := os.LookupEnv(configEnv[key])
+		if !ok {
+			continue
+		}
+		switch key {
+		case "catalogs":
+			config.Catalogs = splitList(value)
+		case "translations":
+			config.Translations = splitList(value)
+		case "files":
+			config.Files = splitList(value)
+		case "dirs":
+			config.Dirs = splitList(value)
+		case "cacheDir":
+			config.CacheDir = value
+		case "canon":
+			config.Canon = value
+		case "versification":
+			config.Versification = value
+		case "layout":
+			config.Layout = value
+		case "format":
+			config.Format = value
+		case "width":
+			if config.Width, err = strconv.Atoi(value); err != nil {
+				return config, sources, fmt.Errorf("%s must be a number: %w", configEnv[key], err)
+			}
+		}
+		sources[key] = "env " + configEnv[key]
+	}
+	return config, sources, nil
+}
+
+// listFlag is a flag holding a list that starts out as the configured
+// list.  The first time the flag is given it replaces that list, and
+// each later time it adds to it.  With split, each value may itself be a
+// comma separated list.
+type listFlag struct {
+	list  *[]string
+	split bool
+	given bool
+}
+
+func (l *listFlag) String() string {
+	if l.list == nil {
+		return ""
+	}
+	return strings.Join(*l.list, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	if !l.given {
+		*l.list, l.given = nil, true
+	}
+	if l.split {
+		*l.list = append(*l.list, splitList(value)...)
+	} else {
+		*l.list = append(*l.list, value)
+	}
+	return nil
+}
+
+// showConfig writes the settings in effect and where each one came from
+func showConfig(w io.Writer, config Config, sources map[string]string, path string) error {
+	fmt.Fprintf(w, "Config file: %s\n", path)
+	fmt.Fprintln(w, "Each setting comes from the first of: flag, environment variable, config file, default")
+	fmt.Fprintln(w)
+	values := map[string]string{
+		"catalogs":      strings.Join(config.Catalogs, ","),
+		"translations":  strings.Join(config.Translations, ","),
+		"files":         strings.Join(config.Files, ","),
+		"dirs":          strings.Join(config.Dirs, ","),
+		"cacheDir":      config.CacheDir,
+		"canon":         config.Canon,
+		"versification": config.Versification,
+		"layout":        config.Layout,
+		"width":         strconv.Itoa(config.Width),
+		"format":        config.Format,
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tVALUE\tSOURCE\tFLAG\tENVIRONMENT")
+	for _, key := range configKeys {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t-%s\t%s\n", key, values[key], sources[key], configFlags[key], configEnv[key])
+	}
+	return tw.Flush()
+}
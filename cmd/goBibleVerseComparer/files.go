@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/botanyhelp/goBibleVerseComparer/bible"
+)
+
+// splitFileTitle splits a -file value of path[:title] into the path and
+// title.  Without a title the path is used as the title.  A colon only
+// starts the title when what follows it is not part of a path, so
+// C:\bibles\draft.txt is a path alone.
+func splitFileTitle(spec string) (string, string) {
+	i := strings.LastIndex(spec, ":")
+	if i <= 1 || strings.ContainsAny(spec[i+1:], `/\`) {
+		return spec, spec
+	}
+	path, title := spec[:i], strings.TrimSpace(spec[i+1:])
+	if title == "" {
+		return path, path
+	}
+	return path, title
+}
+
+// bibleFilesIn returns the .txt files in dir, sorted by name.  Whether
+// each one really is a bible is only known once it is read.
+func bibleFilesIn(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), ".txt") {
+			paths = append(paths, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return paths, nil
+}
+
+// loadBibleFile reads the bible text at path and gives it title, using
+// a snapshot in cacheDir when there is a current one.  Text with no
+// verses in it is an error, so stray notes in a directory are not taken
+// for bibles.
+func loadBibleFile(path, title, cacheDir string) (*bible.Translation, int, error) {
+	text, err := bible.FetchBibleTextFromFile(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	location, err := filepath.Abs(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	rope, err := readRope(text, location, cacheDir)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(rope.Books) == 0 {
+		return nil, 0, fmt.Errorf("no verses found")
+	}
+	return bible.NewTranslation(bible.TranslationCode(path), title, rope), strings.Count(text, "\n"), nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/botanyhelp/goBibleVerseComparer/bible"
+)
+
+// referenceExamples show the ways a reference can be typed
+var referenceExamples [][2]string = [][2]string{
+	{"John 3:16", "a verse"},
+	{"Jn 3:16", "book names may be shortened"},
+	{"1 Cor 13", "a whole chapter"},
+	{"Matt 5:3-12", "a range of verses"},
+	{"Gen 1-3", "a range of chapters"},
+	{"Gen 1:26-2:3", "a range that crosses chapters"},
+}
+
+// bookPromptHelp explains the book prompt
+func bookPromptHelp() string {
+	return "Type a book, like 'Genesis' or '2 Corinthians', and you will be asked for a chapter and then a verse, or type a whole reference to see it at once.  'books' lists the books."
+}
+
+// chapterPromptHelp explains the chapter prompt for book
+func (r *repl) chapterPromptHelp(book string) string {
+	return fmt.Sprintf("Type the number of a chapter of %s, which has chapters %s.", book, bible.FormatNumberRanges(bible.ChaptersOf(r.ropes, book)))
+}
+
+// versePromptHelp explains the verse prompt for book and chapter
+func (r *repl) versePromptHelp(book string, chapter int) string {
+	return fmt.Sprintf("Type the number of a verse of %s %d, which has verses %s, a range of them like '3-12', or 'all' for the whole chapter.", book, chapter, bible.FormatNumberRanges(bible.VersesOf(r.ropes, book, chapter)))
+}
+
+// batchHelp explains the lines batch mode reads
+func batchHelp() string {
+	return "Each line is a reference or a command.  Blank lines and lines starting with # are skipped."
+}
+
+// writeHelp writes the help for the prompt being answered, which
+// promptHelp explains, with the ways of typing references and the commands
+func writeHelp(w io.Writer, promptHelp string, width int) {
+	fmt.Fprintln(w)
+	for _, line := range wrapText(promptHelp, width, "") {
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintln(w, "\nReferences can be typed like these:")
+	for _, example := range referenceExamples {
+		fmt.Fprintf(w, "  %-14s%s\n", example[0], example[1])
+	}
+	fmt.Fprintln(w, "\nThese commands work at every prompt, and 'help' and a command, like 'help use', says more:")
+	commands := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, command := range replCommands {
+		fmt.Fprintf(commands, "  %s\t%s\n", command.synopsis(), command.summary)
+	}
+	commands.Flush()
+}
+
+// bookLines lays books out in columns no wider than width, under the
+// testament each belongs to.  Books that are not in the New Testament,
+// like the deuterocanon, go with the Old.
+func bookLines(books []string, width int) []string {
+	var oldTestament, newTestament []string
+	for _, book := range books {
+		if bible.IsNewTestament(book) {
+			newTestament = append(newTestament, book)
+		} else {
+			oldTestament = append(oldTestament, book)
+		}
+	}
+	var lines []string
+	for _, testament := range []struct {
+		title string
+		books []string
+	}{{"Old Testament", oldTestament}, {"New Testament", newTestament}} {
+		if len(testament.books) == 0 {
+			continue
+		}
+		lines = append(lines, testament.title+":")
+		for _, line := range columnLines(testament.books, width-2) {
+			lines = append(lines, "  "+line)
+		}
+	}
+	return lines
+}
+
+// writeBooks writes bookLines to w
+func writeBooks(w io.Writer, books []string, width int) {
+	fmt.Fprintln(w, strings.Join(bookLines(books, width), "\n"))
+}
@@ -0,0 +1,346 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// maxHistory is how many lines the history file keeps
+const maxHistory int = 1000
+
+// lineEditor reads lines from a terminal in raw mode, so they can be
+// edited with the arrow keys, recalled from the history and completed with
+// Tab.  It understands the usual keys of a shell:
+//
+//	Left, Right, Ctrl+B, Ctrl+F    move the cursor
+//	Home, End, Ctrl+A, Ctrl+E      go to the start or end of the line
+//	Up, Down, Ctrl+P, Ctrl+N       go back and forth through the history
+//	Backspace, Delete, Ctrl+D      delete a character
+//	Ctrl+U, Ctrl+K, Ctrl+W         delete to the start, the end, or a word
+//	Tab                            complete, or list the completions
+//	Ctrl+C                         start the line again
+//	Ctrl+D on an empty line        end the input
+type lineEditor struct {
+	in  *bufio.Reader
+	out io.Writer
+	// raw puts the terminal into raw mode and returns the function that
+	// restores it
+	raw func() (func(), error)
+
+	history []string
+	// historyPath is the file the history is kept in between sessions;
+	// empty keeps it for this session only
+	historyPath string
+	// width is the width of the terminal, 0 if it is not known
+	width int
+}
+
+// newTerminalEditor returns a line editor for the terminal on stdin and
+// stdout, with the history kept in historyPath
+func newTerminalEditor(in *bufio.Reader, historyPath string) *lineEditor {
+	width, _ := terminalSize()
+	editor := &lineEditor{
+		in:          in,
+		out:         os.Stdout,
+		raw:         func() (func(), error) { return makeRaw(os.Stdin.Fd()) },
+		historyPath: historyPath,
+		width:       width,
+	}
+	editor.loadHistory()
+	return editor
+}
+
+// loadHistory reads the history file, if there is one
+func (e *lineEditor) loadHistory() {
+	if e.historyPath == "" {
+		return
+	}
+	data, err := os.ReadFile(e.historyPath)
+	if err != nil {
+		return
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line != "" {
+			e.history = append(e.history, line)
+		}
+	}
+	e.history = e.history[max(0, len(e.history)-maxHistory):]
+}
+
+// addHistory remembers line, unless it repeats the line before it, and
+// saves the history, trimmed to its last maxHistory lines
+func (e *lineEditor) addHistory(line string) {
+	if line == "" || (len(e.history) > 0 && e.history[len(e.history)-1] == line) {
+		return
+	}
+	e.history = append(e.history, line)
+	e.history = e.history[max(0, len(e.history)-maxHistory):]
+	if e.historyPath == "" {
+		return
+	}
+	// the history is only a convenience, so failing to save it is not reported
+	if err := os.MkdirAll(filepath.Dir(e.historyPath), 0o755); err == nil {
+		os.WriteFile(e.historyPath, []byte(strings.Join(e.history, "\n")+"\n"), 0o600)
+	}
+}
+
+// readLine prints prompt and returns the line typed.  complete, when not
+// nil, returns the ways the text before the cursor could be completed,
+// each the whole of that text completed.  It returns io.EOF when the
+// input ends.
+func (e *lineEditor) readLine(prompt string, complete func(string) []string) (string, error) {
+	restore, err := e.raw()
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+
+	fmt.Fprint(e.out, prompt)
+	// only the last line of the prompt is drawn again as the line changes,
+	// which only works while it and the line fit on one row, so a long
+	// prompt gets a row of its own and the line is typed after a short one
+	promptLine := prompt[strings.LastIndex(prompt, "\n")+1:]
+	if e.width > 0 && len(promptLine) > e.width/2 {
+		promptLine = "> "
+		fmt.Fprint(e.out, "\r\n"+promptLine)
+	}
+	var line []rune
+	cursor := 0
+	// historyIndex is the history line shown, len(e.history) being the new line
+	historyIndex := len(e.history)
+	redraw := func() {
+		fmt.Fprintf(e.out, "\r%s%s\x1b[K", promptLine, string(line))
+		if back := len(line) - cursor; back > 0 {
+			fmt.Fprintf(e.out, "\x1b[%dD", back)
+		}
+	}
+	setLine := func(text string) {
+		line = []rune(text)
+		cursor = len(line)
+		redraw()
+	}
+	lastWasTab := false
+
+	for {
+		key, _, err := e.in.ReadRune()
+		if err != nil {
+			fmt.Fprint(e.out, "\r\n")
+			if len(line) > 0 {
+				return string(line), nil
+			}
+			return "", io.EOF
+		}
+		tab := false
+		switch key {
+		case '\r', '\n':
+			fmt.Fprint(e.out, "\r\n")
+			e.addHistory(strings.TrimSpace(string(line)))
+			return string(line), nil
+		case 3: // Ctrl+C
+			fmt.Fprint(e.out, "^C\r\n")
+			line, cursor, historyIndex = nil, 0, len(e.history)
+			redraw()
+		case 4: // Ctrl+D
+			if len(line) == 0 {
+				fmt.Fprint(e.out, "\r\n")
+				return "", io.EOF
+			}
+			if cursor < len(line) {
+				line = slices.Delete(line, cursor, cursor+1)
+				redraw()
+			}
+		case 127, 8: // Backspace
+			if cursor > 0 {
+				line = slices.Delete(line, cursor-1, cursor)
+				cursor--
+				redraw()
+			}
+		case 1: // Ctrl+A
+			cursor = 0
+			redraw()
+		case 5: // Ctrl+E
+			cursor = len(line)
+			redraw()
+		case 2: // Ctrl+B
+			cursor = max(0, cursor-1)
+			redraw()
+		case 6: // Ctrl+F
+			cursor = min(len(line), cursor+1)
+			redraw()
+		case 11: // Ctrl+K
+			line = line[:cursor]
+			redraw()
+		case 21: // Ctrl+U
+			line = slices.Delete(line, 0, cursor)
+			cursor = 0
+			redraw()
+		case 23: // Ctrl+W
+			start := cursor
+			for start > 0 && line[start-1] == ' ' {
+				start--
+			}
+			for start > 0 && line[start-1] != ' ' {
+				start--
+			}
+			line = slices.Delete(line, start, cursor)
+			cursor = start
+			redraw()
+		case 16: // Ctrl+P
+			if historyIndex > 0 {
+				historyIndex--
+				setLine(e.history[historyIndex])
+			}
+		case 14: // Ctrl+N
+			if historyIndex < len(e.history) {
+				historyIndex++
+				setLine(e.historyLine(historyIndex))
+			}
+		case '\t':
+			tab = true
+			if complete == nil {
+				break
+			}
+			before := string(line[:cursor])
+			completions := complete(before)
+			switch {
+			case len(completions) == 0:
+				fmt.Fprint(e.out, "\a")
+			case len(completions) == 1 || len(commonPrefix(completions)) > len(before):
+				completed := commonPrefix(completions)
+				if len(completions) == 1 {
+					completed = completions[0]
+				}
+				line = append([]rune(completed), line[cursor:]...)
+				cursor = len([]rune(completed))
+				redraw()
+			case lastWasTab:
+				// a second Tab with nothing more to add lists the choices
+				fmt.Fprint(e.out, "\r\n")
+				for _, row := range columnLines(completionWords(before, completions), max(e.width, 40)) {
+					fmt.Fprint(e.out, row+"\r\n")
+				}
+				redraw()
+			default:
+				fmt.Fprint(e.out, "\a")
+			}
+		case 27: // the start of an escape sequence, like the arrow keys
+			switch e.readEscape() {
+			case "[A", "OA":
+				if historyIndex > 0 {
+					historyIndex--
+					setLine(e.history[historyIndex])
+				}
+			case "[B", "OB":
+				if historyIndex < len(e.history) {
+					historyIndex++
+					setLine(e.historyLine(historyIndex))
+				}
+			case "[C", "OC":
+				cursor = min(len(line), cursor+1)
+				redraw()
+			case "[D", "OD":
+				cursor = max(0, cursor-1)
+				redraw()
+			case "[H", "OH", "[1~", "[7~":
+				cursor = 0
+				redraw()
+			case "[F", "OF", "[4~", "[8~":
+				cursor = len(line)
+				redraw()
+			case "[3~":
+				if cursor < len(line) {
+					line = slices.Delete(line, cursor, cursor+1)
+					redraw()
+				}
+			}
+		default:
+			if key >= ' ' {
+				line = slices.Insert(line, cursor, key)
+				cursor++
+				redraw()
+			}
+		}
+		lastWasTab = tab
+	}
+}
+
+// historyLine is the history line at i, or the empty new line past the end
+func (e *lineEditor) historyLine(i int) string {
+	if i >= len(e.history) {
+		return ""
+	}
+	return e.history[i]
+}
+
+// readEscape reads the rest of an escape sequence after the escape, like
+// "[A" for the up arrow or "[3~" for Delete
+func (e *lineEditor) readEscape() string {
+	first, _, err := e.in.ReadRune()
+	if err != nil || (first != '[' && first != 'O') {
+		return ""
+	}
+	sequence := []rune{first}
+	for {
+		next, _, err := e.in.ReadRune()
+		if err != nil {
+			return ""
+		}
+		sequence = append(sequence, next)
+		// parameters are digits and semicolons, and anything else ends it
+		if (next < '0' || next > '9') && next != ';' {
+			return string(sequence)
+		}
+	}
+}
+
+// commonPrefix returns the longest prefix all of words share
+func commonPrefix(words []string) string {
+	if len(words) == 0 {
+		return ""
+	}
+	prefix := words[0]
+	for _, word := range words[1:] {
+		for !strings.HasPrefix(word, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+// completionWords shortens each completion of before to the word being
+// completed, so "1 " lists John, Kings and so on rather than whole lines
+func completionWords(before string, completions []string) []string {
+	start := strings.LastIndexAny(before, " :") + 1
+	words := make([]string, 0, len(completions))
+	for _, completion := range completions {
+		words = append(words, strings.TrimSpace(completion[min(start, len(completion)):]))
+	}
+	return words
+}
+
+// columnLines lays words out in as many columns as fit in width, filling
+// each column top to bottom, and returns the rows
+func columnLines(words []string, width int) []string {
+	columnWidth := 0
+	for _, word := range words {
+		columnWidth = max(columnWidth, len(word)+2)
+	}
+	columns := max(1, width/max(columnWidth, 1))
+	rows := (len(words) + columns - 1) / columns
+	lines := make([]string, 0, rows)
+	for row := 0; row < rows; row++ {
+		var b strings.Builder
+		for column := 0; column < columns; column++ {
+			if i := column*rows + row; i < len(words) {
+				fmt.Fprintf(&b, "%-*s", columnWidth, words[i])
+			}
+		}
+		lines = append(lines, strings.TrimRight(b.String(), " "))
+	}
+	return lines
+}
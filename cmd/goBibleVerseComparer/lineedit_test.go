@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// newTestEditor returns an editor reading keys, with no real terminal
+func newTestEditor(keys string, historyPath string) *lineEditor {
+	return &lineEditor{
+		in:          bufio.NewReader(strings.NewReader(keys)),
+		out:         io.Discard,
+		raw:         func() (func(), error) { return func() {}, nil },
+		historyPath: historyPath,
+	}
+}
+
+func TestLineEditorKeys(t *testing.T) {
+	tests := []struct {
+		name, keys, want string
+	}{
+		{"typing", "John 3:16\r", "John 3:16"},
+		{"backspace", "Jonn\x7f\x7fhn 3\r", "John 3"},
+		{"left arrow and insert", "Jn 3\x1b[D\x1b[D\x1b[Do\r", "Jon 3"},
+		{"home and end", "ohn\x1b[HJ\x1b[F 1\r", "John 1"},
+		{"ctrl+a and ctrl+k", "Gen 1\x01\x0bMark 2\r", "Mark 2"},
+		{"ctrl+w", "Genesis 1:1 and more\x17\x17\r", "Genesis 1:1 "},
+		{"ctrl+u", "Genesis\x15Exodus\r", "Exodus"},
+		{"delete", "XGen\x01\x1b[3~\r", "Gen"},
+		{"ctrl+c starts again", "Gen\x03Ex 1\r", "Ex 1"},
+		{"newline", "Gen 1\n", "Gen 1"},
+	}
+	for _, test := range tests {
+		editor := newTestEditor(test.keys, "")
+		got, err := editor.readLine("> ", nil)
+		if err != nil || got != test.want {
+			t.Errorf("%s: readLine = %q, %v, want %q", test.name, got, err, test.want)
+		}
+	}
+}
+
+func TestLineEditorEOF(t *testing.T) {
+	for _, keys := range []string{"", "\x04"} {
+		if _, err := newTestEditor(keys, "").readLine("> ", nil); !errors.Is(err, io.EOF) {
+			t.Errorf("readLine of %q: err = %v, want io.EOF", keys, err)
+		}
+	}
+}
+
+func TestLineEditorHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+	editor := newTestEditor("John 3:16\rGen 1\rGen 1\r\x1b[A\x1b[A\x1b[A\x1b[B\r", path)
+	var lines []string
+	for range 4 {
+		line, err := editor.readLine("> ", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, line)
+	}
+	// up three times stops at the oldest line and down goes to the next
+	if want := []string{"John 3:16", "Gen 1", "Gen 1", "Gen 1"}; !slices.Equal(lines, want) {
+		t.Errorf("lines = %q, want %q", lines, want)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "John 3:16\nGen 1\n"; string(data) != want {
+		t.Errorf("history file is %q, want %q", data, want)
+	}
+	// a new session starts with the saved history
+	editor = newTestEditor("\x1b[A\r", path)
+	editor.loadHistory()
+	if line, err := editor.readLine("> ", nil); err != nil || line != "Gen 1" {
+		t.Errorf("readLine after loading the history = %q, %v, want Gen 1", line, err)
+	}
+}
+
+func TestLineEditorCompletion(t *testing.T) {
+	prompter, _ := newTestRepl(t, "")
+	tests := []struct{ keys, want string }{
+		{"gen\t1:\t\r", "Genesis 1:"},
+		{"Mal\t\t5\r", "Malachi 4:5"},
+		{"Ps\t\t\r", "Psalm "},
+		{"Jo\t\t\r", "John 3:"},
+		{"n\t\t\r", "n"},
+		{"Hez\t\r", "Hez"},
+	}
+	for _, test := range tests {
+		editor := newTestEditor(test.keys, "")
+		if got, err := editor.readLine("> ", prompter.completeReference); err != nil || got != test.want {
+			t.Errorf("readLine of %q = %q, %v, want %q", test.keys, got, err, test.want)
+		}
+	}
+}
+
+func TestCompleteReference(t *testing.T) {
+	prompter, _ := newTestRepl(t, "")
+	tests := []struct {
+		before string
+		want   []string
+	}{
+		{"ge", []string{"Genesis "}},
+		{"3 J", []string{"3 John "}},
+		{"Psalm ", []string{"Psalm 23", "Psalm 51"}},
+		{"Psalm 2", []string{"Psalm 23:"}},
+		{"Ps 23:", []string{"Ps 23:1", "Ps 23:2"}},
+		{"Matthew 1", nil},
+		{"Zz", nil},
+	}
+	for _, test := range tests {
+		if got := prompter.completeReference(test.before); !slices.Equal(got, test.want) {
+			t.Errorf("completeReference(%q) = %q, want %q", test.before, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,457 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"bufio"
+	"strings"
+	"os"
+	"path/filepath"
+	"flag"
+	"slices"
+
+	"github.com/botanyhelp/goBibleVerseComparer/bible"
+)
+
+
+// printVerse writes ref, which is numbered in scheme, from every translation
+// to w, each after the title of the translation and wrapped to width
+func printVerse(w io.Writer, translations []*bible.Translation, ref bible.VerseRef, scheme *bible.Versification, width int, s style) {
+	// Print the collected values
+	fmt.Fprintf(w, "%s\n", s.heading(ref.String()))
+	var texts []verseText
+	for _, result := range bible.Compare(translations, ref, scheme) {
+		text := verseText{label: result.Title}
+		if result.Found {
+			text.text = result.Text
+			if len(result.Resolved) > 0 {
+				// this translation numbers the verse differently, so show where it found it
+				var refStrings []string
+				for _, resolvedRef := range result.Resolved {
+					refStrings = append(refStrings, resolvedRef.String())
+				}
+				text.note = fmt.Sprintf("[%s]", strings.Join(refStrings, ", "))
+			}
+		} else if !result.HasBook {
+			// say so, rather than silently skip, when a translation lacks the whole book
+			text.note = fmt.Sprintf("[%s is not in this translation]", ref.Book)
+		} else {
+			text.note = "[omitted in this translation]"
+		}
+		texts = append(texts, text)
+	}
+	for _, line := range renderVerses(texts, width, s) {
+		fmt.Fprintln(w, line)
+	}
+}
+
+func main() {
+	// debug true will print too much information (got love if you want it -Bob Dylan)
+	var debug bool = false
+	if debug { fmt.Printf("Mr. Rogers loves you\n")}
+
+	// a first argument that is not a flag names a subcommand: serve, catalog, config, mark, plan, votd, random or xref
+	var subcommand string
+	var args []string = os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		subcommand, args = args[0], args[1:]
+	}
+	// commandArgs are the words after mark, plan, votd, random or xref, up to the flags
+	var commandArgs []string
+	switch subcommand {
+	case "", "serve", "catalog":
+	case "config":
+		if len(args) == 0 || args[0] != "show" {
+			log.Fatal("usage: config show [flags], which prints the settings those flags would give")
+		}
+		args = args[1:]
+	case "mark", "plan", "votd", "random", "xref":
+		for len(args) > 0 && !(len(args[0]) > 1 && strings.HasPrefix(args[0], "-")) {
+			commandArgs, args = append(commandArgs, args[0]), args[1:]
+		}
+		if subcommand == "plan" && len(commandArgs) > 0 && !slices.Contains(planCommands, commandArgs[0]) {
+			log.Fatal("usage: plan [today|day|done|undo|start|status|list|stop ...], like: plan start year, or: plan -translations kjv,web")
+		}
+		if subcommand == "mark" && (len(commandArgs) == 0 || !slices.Contains(markCommands, commandArgs[0])) {
+			log.Fatal("usage: mark add|list|go|tag|note|rm ..., like: mark add John 3:16 '#love' a note, or: mark go 1 -translations kjv,web")
+		}
+	default:
+		log.Fatalf("unknown command %q, use serve, catalog, config, mark, plan, votd, random or xref, or no command to be prompted", subcommand)
+	}
+	// without a config directory there is nowhere to keep bookmarks, which mark says
+	bookmarksFile, _ := bookmarksPath()
+	notesFile, _ := notesPath()
+	planFile, _ := planPath()
+	xrefFile, _ := crossReferencesPath()
+
+	// config starts out as the defaults, the config file and the environment,
+	// and the flags below override it
+	configFile, err := configPath(args)
+	if err != nil {
+		log.Fatal(err)
+	}
+	config, sources, err := loadConfig(configFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 'catalog' as the first argument lists or adds to the catalog of bibles instead
+	if subcommand == "catalog" {
+		if err := runCatalogCommand(args, config); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// 'mark' keeps bookmarks, and only needs the bibles to show them with 'mark go'
+	if subcommand == "mark" && commandArgs[0] != "go" {
+		books, err := bible.CanonBooks(config.Canon, nil, nil)
+		if err != nil {
+			log.Fatal(err)
+		}
+		width, _ := terminalSize()
+		marker := &repl{out: os.Stdout, books: books, width: width, style: newStyle(os.Stdout), bookmarksPath: bookmarksFile}
+		if _, err := marker.markCommand(strings.Join(commandArgs, " ")); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	flag.String("config", configFile, "the JSON config file to read settings from; GOBIBLE_CONFIG also sets it")
+	var book string
+	flag.StringVar(&book, "book", "Mark", "the name of the book, Genesis, Mark, Luke, capitalized")
+	var chapterNumber int
+	flag.IntVar(&chapterNumber, "chapterNumber", 1, "the number of the chapter, like 3 in John 3:16")
+	var verseNumber int
+	flag.IntVar(&verseNumber, "verseNumber", 1, "the number of the verse, like 16 in John 3:16")
+	flag.StringVar(&config.Canon, "canon", config.Canon, "the canon whose books may be looked up: protestant, catholic, orthodox, ethiopian or custom")
+	var customBooks string
+	flag.StringVar(&customBooks, "canonBooks", "", "comma separated book names for -canon custom, in order; empty means every book in the loaded texts")
+
+	flag.StringVar(&config.Versification, "versification", config.Versification, fmt.Sprintf("the verse numbering you type references in, one of %v", bible.VersificationNames()))
+
+	var showCoverage bool
+	flag.BoolVar(&showCoverage, "coverage", false, "print the verses each translation is missing or adds compared with the reference translation, then exit")
+	var coverageReference string
+	flag.StringVar(&coverageReference, "coverageReference", "", "title, or part of the title, of the translation -coverage compares against; empty means the first one loaded")
+
+	flag.StringVar(&config.Layout, "layout", config.Layout, fmt.Sprintf("how passages are shown, one of %v", layouts))
+	flag.IntVar(&config.Width, "width", config.Width, "the width passages are wrapped to; 0 means the terminal width")
+	flag.StringVar(&config.Format, "format", config.Format, fmt.Sprintf("how looked up and found verses are written, one of %v; all but text write the reference, translation, title, text and found of each verse", formats))
+
+	flag.Var(&listFlag{list: &config.Catalogs}, "catalog", "a catalog of bibles to use on top of the built-in one, a URL or a file; may be repeated")
+	flag.Var(&listFlag{list: &config.Translations, split: true}, "translations", "comma separated catalog codes of the bibles to load, like kjv,web")
+	flag.Var(&listFlag{list: &config.Files}, "file", "a local bible text to load, as path or path:title; may be repeated")
+	flag.Var(&listFlag{list: &config.Dirs}, "dir", "a directory whose .txt bible texts are all loaded; may be repeated")
+	flag.StringVar(&config.CacheDir, "cacheDir", config.CacheDir, "the directory downloaded bibles are kept in; off turns caching off")
+
+	var addr string
+	flag.StringVar(&addr, "addr", ":8080", "the address the serve mode listens on")
+
+	// batchMode looks up one reference per line instead of prompting, which
+	// is the default when the input is not a terminal, like a pipe
+	var batchMode bool
+	flag.BoolVar(&batchMode, "batch", !isTerminal(os.Stdin), "read one reference per line, like 'John 3:16', and print each one instead of prompting; the default when the input is not a terminal")
+	var batchInput string
+	flag.StringVar(&batchInput, "input", "-", "the file -batch reads references from; - is the standard input")
+
+	flag.CommandLine.Parse(args)
+	flag.Visit(func(f *flag.Flag) {
+		for key, name := range configFlags {
+			if name == f.Name {
+				sources[key] = "flag -" + f.Name
+			}
+		}
+	})
+
+	if subcommand == "config" {
+		if err := showConfig(os.Stdout, config, sources, configFile); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if !slices.Contains(formats, config.Format) {
+		log.Fatalf("unknown format %q, choose one of %v", config.Format, formats)
+	}
+	// status is where progress goes, which is kept out of the way of structured output
+	var status io.Writer = os.Stdout
+	if config.Format != "text" {
+		status = os.Stderr
+	}
+
+	// 'serve' as the first argument answers the JSON API instead of prompting
+	var serveMode bool = subcommand == "serve"
+
+	var translations []*bible.Translation
+
+	// the bibles are the translations picked from the catalog and the local
+	// files and directories; with none of those, the first 2 in the catalog
+	var translationCodes []string = config.Translations
+	var catalog *bible.Catalog
+	if len(translationCodes) > 0 || len(config.Files)+len(config.Dirs) == 0 {
+		catalog, err = loadCatalogs(config.Catalogs)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if debug { fmt.Printf("catalog: %v\n", catalog.Entries)}
+		if len(translationCodes) == 0 {
+			// TODO: grab 2 bibles, from slice, at random
+			// or just the first 2 for now
+			for _, entry := range catalog.Entries[:min(2, len(catalog.Entries))] {
+				translationCodes = append(translationCodes, entry.Code)
+			}
+		}
+		for _, code := range translationCodes {
+			entry, ok := catalog.Find(code)
+			if !ok {
+				log.Fatalf("%q is not in the catalog, see 'catalog list' for the codes", code)
+			}
+			translation, lines, err := loadCatalogEntry(entry, config.CacheDir)
+			if err != nil {
+				fmt.Fprintf(status, "Error reading %s: %v\n", entry.Title, err)
+				continue
+			}
+			translations = append(translations, translation)
+			fmt.Fprintf(status, "We got %d lines\n", lines)
+		}
+	}
+
+	for _, fileSpec := range config.Files {
+		myFilePath, title := splitFileTitle(fileSpec)
+		translation, lines, err := loadBibleFile(myFilePath, title, config.CacheDir)
+		if err != nil {
+			fmt.Fprintf(status, "Error reading %s: %v\n", myFilePath, err)
+			continue
+		}
+		translations = append(translations, translation)
+		fmt.Fprintf(status, "We got %d lines\n", lines)
+	}
+	for _, dir := range config.Dirs {
+		myFilePaths, err := bibleFilesIn(dir)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, myFilePath := range myFilePaths {
+			translation, lines, err := loadBibleFile(myFilePath, myFilePath, config.CacheDir)
+			if err != nil {
+				fmt.Fprintf(status, "Skipping %s, which is not a bible text: %v\n", myFilePath, err)
+				continue
+			}
+			translations = append(translations, translation)
+			fmt.Fprintf(status, "We got %d lines from %s\n", lines, myFilePath)
+		}
+	}
+	if len(translations) == 0 {
+		log.Fatal("no bibles could be loaded")
+	}
+
+	var bibleRopes []*bible.Rope = bible.Ropes(translations)
+
+	if !slices.Contains(layouts, config.Layout) {
+		log.Fatalf("unknown layout %q, choose one of %v", config.Layout, layouts)
+	}
+
+	// scheme is the versification that chapter and verse numbers typed at the prompts are in
+	scheme, ok := bible.LookupVersification(config.Versification)
+	if !ok {
+		log.Fatalf("unknown versification %q, choose one of %v", config.Versification, bible.VersificationNames())
+	}
+	// promptRopes number verses like scheme, and give the valid chapter and verse numbers at the prompts
+	var promptRopes []*bible.Rope = schemeRopes(translations, scheme)
+
+	var customBookList []string
+	for _, customBook := range strings.Split(customBooks, ",") {
+		if customBook = strings.TrimSpace(customBook); customBook != "" {
+			customBookList = append(customBookList, customBook)
+		}
+	}
+	canonBookList, err := bible.CanonBooks(config.Canon, customBookList, bibleRopes)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// validBooks are the books of the chosen canon that at least one loaded text has
+	var validBooks []string = bible.ValidBooksFor(canonBookList, bibleRopes)
+	if debug { fmt.Printf("validBooks are:\n%v\n", validBooks)}
+	if otherBooks := bible.BooksOutsideCanon(canonBookList, bibleRopes); len(otherBooks) > 0 {
+		fmt.Fprintf(status, "These books are in the loaded texts but not in the %s canon: %v\n", config.Canon, otherBooks)
+	}
+	if showCoverage {
+		var reference *bible.Translation
+		for _, translation := range translations {
+			if reference == nil && strings.Contains(strings.ToLower(translation.Title), strings.ToLower(coverageReference)) {
+				reference = translation
+			}
+		}
+		if reference == nil {
+			log.Fatalf("no loaded translation has %q in its title", coverageReference)
+		}
+		printCoverageReport(os.Stdout, bible.CoverageReport(translations, reference, canonBookList), reference)
+		return
+	}
+
+	if serveMode {
+		api := &apiServer{translations: translations, books: validBooks, ropes: promptRopes, scheme: scheme}
+		if err := serve(addr, api.routes()); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if debug { fmt.Printf("Based on your command-line flags we will look for %s:%d:%d\n", book, chapterNumber, verseNumber) }
+
+	if debug { fmt.Println("Otherwise, enter some text (press Ctrl+D or Ctrl+Z and Enter to finish):") }
+
+	terminalWidth, terminalHeight := terminalSize()
+	if config.Width > 0 {
+		terminalWidth = config.Width
+	}
+	if !isTerminal(os.Stdout) {
+		terminalHeight = 0
+	}
+	prompter := &repl{
+		in:            bufio.NewReader(os.Stdin),
+		out:           os.Stdout,
+		loaded:        translations,
+		translations:  translations,
+		catalogs:      config.Catalogs,
+		catalog:       catalog,
+		cacheDir:      config.CacheDir,
+		canon:         config.Canon,
+		canonBooks:    canonBookList,
+		books:         validBooks,
+		ropes:         promptRopes,
+		scheme:        scheme,
+		layout:        config.Layout,
+		format:        config.Format,
+		width:         terminalWidth,
+		height:        terminalHeight,
+		style:         newStyle(os.Stdout),
+		bookmarksPath: bookmarksFile,
+		notesPath:     notesFile,
+		planPath:      planFile,
+		xrefPath:      xrefFile,
+	}
+
+	// mark, plan, votd, random and xref run once, as they would at the prompt
+	switch subcommand {
+	case "mark", "plan", "votd", "random", "xref":
+		if _, err := findCommand(subcommand).run(prompter, strings.Join(commandArgs, " ")); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if batchMode || batchInput != "-" {
+		if batchInput != "-" {
+			batchFile, err := os.Open(batchInput)
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer batchFile.Close()
+			prompter.in = bufio.NewReader(batchFile)
+		}
+		prompter.height = 0
+		if err := prompter.runBatch(os.Stderr); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// at a terminal, lines can be edited, recalled and completed
+	if isTerminal(os.Stdin) && isTerminal(os.Stdout) {
+		historyPath := ""
+		if dir, err := configDir(); err == nil {
+			historyPath = filepath.Join(dir, "history")
+		}
+		prompter.editor = newTerminalEditor(prompter.in, historyPath)
+	}
+	if err := prompter.run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+	// Adding some "segments" to our conceptual rope
+	//myRope.AddSegment("2 Samuel", 13, 28, "Now Absalom had commanded his servants, saying, Mark ye now when Amnon’s heart is merry with wine, and when I say unto you, Smite Amnon; then kill him, fear not: have not I commanded you? be courageous, and be valiant.")
+	//myRope.AddSegment("1 Kings", 20, 7, "Then the king of Israel called all the elders of the land, and said, Mark, I pray you, and see how this [man] seeketh mischief: for he sent unto me for my wives, and for my children, and for my silver, and for //my gold; and I denied him not.")
+	//myRope.AddSegment("Job", 21, 5, "Mark me, and be astonished, and lay [your] hand upon [your] mouth.")
+	//myRope.AddSegment("Job", 33, 31, "Mark well, O Job, hearken unto me: hold thy peace, and I will speak.")
+	//myRope.AddSegment("Psalm", 37, 37, "Mark the perfect [man], and behold the upright: for the end of [that] man [is] peace.")
+	//myRope.AddSegment("Psalm", 48, 13, "Mark ye well her bulwarks, consider her palaces; that ye may tell [it] to the generation following.")
+	//myRope.AddSegment("Mark", 1, 1, "The beginning of the gospel of Jesus Christ, the Son of God;")
+	//myRope.AddSegment("Mark", 1, 2, "As it is written in the prophets, Behold, I send //my messenger before thy face, which shall prepare thy way before thee.")
+	//myRope.AddSegment("Mark", 1, 3, "The voice of one crying in the wilderness, Prepare ye the way of the Lord, make his paths straight.")
+	//myRope.AddSegment("Mark", 1, 4, "John did baptize in the wilderness, and preach the baptism of repentance for the remission of sins.")
+
+	// Retrieving segment content
+	//content1, found1 := myRope.GetSegmentContent("2 Samuel", 13, 28)
+	//if found1 {
+	//	fmt.Printf("Segment '2 Samuel': %s\n", content1)
+	//}
+
+	//content2, found2 := myRope.GetSegmentContent("Psalm", 48, 13)
+	//if found2 {
+	//	fmt.Printf("Segment 'Psalm': %s\n", content2)
+	//}
+
+	//content3, found3 := myRope.GetSegmentContent("Mark", 1, 3)
+	//if found3 {
+	//	fmt.Printf("Segment 'Mark': %s\n", content3)
+	//}
+
+	//content4, found4 := myRope.GetSegmentContent("Genesis", 1, 10)
+	//if found4 {
+	//	fmt.Printf("Segment 'Genesis': %s\n", content4)
+	//}
+
+//"https://openbible.com/textfiles/bsb.txt",
+//"https://openbible.com/textfiles/brb.txt",
+//"https://openbible.com/textfiles/asv.txt",
+//"https://openbible.com/textfiles/akjv.txt",
+//"https://openbible.com/textfiles/cpdv.txt",
+//"https://openbible.com/textfiles/dbt.txt",
+//"https://openbible.com/textfiles/drb.txt",
+//"https://openbible.com/textfiles/erv.txt",
+//"https://openbible.com/textfiles/jps.txt",
+//"https://openbible.com/textfiles/kjv.txt",
+//"https://openbible.com/textfiles/slt.txt",
+//"https://openbible.com/textfiles/wvt.txt",
+//"https://openbible.com/textfiles/web.txt",
+//"https://openbible.com/textfiles/ylt.txt",
+//"https://archive.org/download/cuv_20220420/CUV_txt.tar.gz",
+
+//	// these were good URLs in Sept 2025
+//	// the first 14 are all similar in this way
+//	// 1. they have the first 2 lines of non-verse garbage to discard
+//	// 2. they all have one verse line in the same format like this:
+//	// #(.*) ([0-9][0-9]*):([0-9][0-9]*)\t(.*)#
+//	// ..which might work with golang regexp package
+//	// the last one is tar gzip but has good and uniform chinese with 13 lines of non-verse at the top of the file
+//	var bibles [15]string = [15]string{"https://openbible.com/textfiles/bsb.txt","https://openbible.com/textfiles/brb.txt","https://openbible.com/textfiles/asv.txt","https://openbible.com/textfiles/akjv.txt","https://openbible.com/textfiles/cpdv.txt","https://openbible.com/textfiles/dbt.txt","https://openbible.com/textfiles/drb.txt","https://openbible.com/textfiles/erv.txt","https://openbible.com/textfiles/jps.txt","https://openbible.com/textfiles/kjv.txt","https://openbible.com/textfiles/slt.txt","https://openbible.com/textfiles/wvt.txt","https://openbible.com/textfiles/web.txt","https://openbible.com/textfiles/ylt.txt","https://archive.org/download/cuv_20220420/CUV_txt.tar.gz"}
+//	//LDS
+//	//https://github.com/beandog/lds-scriptures/archive/2020.12.08.zip
+//	//cp /var/tmp/lds-scriptures-2020.12.08/text/kjv-scriptures.txt ~/goStuff/bibleone/
+//	//https://scriptures.nephi.org/mysql
+//	//SELECT vol.volume_title, b.book_title, c.chapter_number, v.scripture_text FROM volumes vol JOIN books b on b.volume_id = vol.id JOIN chapters c ON c.book_id = b.id JOIN verses v ON v.chapter_id = c.id WHERE b.book_title = 'John' AND c.chapter_number = 3 AND v.verse_number = 16;
+//	//SELECT scripture_text FROM scriptures WHERE verse_title = 'John 3:16';
+//	//
+//	//https://scriptures.nephi.org/postgresql
+//	//SELECT vol.volume_title, b.book_title, c.chapter_number, v.scripture_text FROM volumes vol JOIN books b on b.volume_id = vol.id JOIN chapters c ON c.book_id = b.id JOIN verses v ON v.chapter_id = c.id WHERE b.book_title = 'John' AND c.chapter_number = 3 AND v.verse_number = 16;
+//	//SELECT scripture_text FROM scriptures WHERE verse_title = 'John 3:16';
+//	for _,bible := range(bibles) {
+//		if debug {
+//			fmt.Printf("%s\n",bible)
+//		}
+//	}
+
+// fetchBibleUrls retrieves the http url argument with http.Get,
+// gets entire body with ioutil.ReadAll and returns a map[string][string]
+//that holds the title-of-bible mapped to the URL where it can be retrieved
+// we want something like this map:
+//
+// bibleTextTitles := map[string]string{
+// 	"Berean Standard Bible":  "https://bereanbible.com/bsb.txt",
+// 	"Catholic Public Domain Version": "https://bereanbible.com/cpdv.txt",
+// }
+//
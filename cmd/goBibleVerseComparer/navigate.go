@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/botanyhelp/goBibleVerseComparer/bible"
+)
+
+// navigate works out the passage to show for a navigation command, one of
+// next, prev, nextChapter and prevChapter, relative to the last passage shown.
+// Verse commands give a single verse and chapter commands a whole chapter.
+func navigate(command string, last bible.Passage, books []string, ropes []*bible.Rope) (bible.Passage, error) {
+	if last.Book == "" {
+		return bible.Passage{}, fmt.Errorf("nothing has been shown yet, so there is no next or previous")
+	}
+	switch command {
+	case "next":
+		end, ok := last.LastVerse(ropes)
+		if ok {
+			end, ok = bible.NextVerse(end, books, ropes)
+		}
+		if !ok {
+			return bible.Passage{}, fmt.Errorf("there is no verse after %s", last)
+		}
+		return bible.VersePassage(end), nil
+	case "prev":
+		start, ok := last.FirstVerse(ropes)
+		if ok {
+			start, ok = bible.PrevVerse(start, books, ropes)
+		}
+		if !ok {
+			return bible.Passage{}, fmt.Errorf("there is no verse before %s", last)
+		}
+		return bible.VersePassage(start), nil
+	case "nextChapter":
+		book, chapter, ok := bible.NextChapter(last.Book, last.EndChapter, books, ropes)
+		if !ok {
+			return bible.Passage{}, fmt.Errorf("there is no chapter after %s %d", last.Book, last.EndChapter)
+		}
+		return bible.ChapterPassage(book, chapter), nil
+	case "prevChapter":
+		book, chapter, ok := bible.PrevChapter(last.Book, last.StartChapter, books, ropes)
+		if !ok {
+			return bible.Passage{}, fmt.Errorf("there is no chapter before %s %d", last.Book, last.StartChapter)
+		}
+		return bible.ChapterPassage(book, chapter), nil
+	}
+	return bible.Passage{}, fmt.Errorf("unknown navigation command %q", command)
+}
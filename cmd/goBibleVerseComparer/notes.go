@@ -0,0 +1,326 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/botanyhelp/goBibleVerseComparer/bible"
+)
+
+// noteCommands are the words that may follow note
+var noteCommands []string = []string{"add", "list", "search", "rm", "export"}
+
+// annotation is a note on a verse or a run of verses.  The verses are
+// kept in KJV numbering, whatever numbering they were typed in, so the
+// note is shown with them in every translation and at every prompt.
+type annotation struct {
+	Book string `json:"book"`
+	// Start and End are the chapter and verse of the first and last verses
+	Start [2]int    `json:"start"`
+	End   [2]int    `json:"end"`
+	Text  string    `json:"text"`
+	Tags  []string  `json:"tags,omitempty"`
+	Added time.Time `json:"added"`
+}
+
+// passage is the verses the note is on, in KJV numbering
+func (a annotation) passage() bible.Passage {
+	return bible.Passage{Book: a.Book, StartChapter: a.Start[0], StartVerse: a.Start[1], EndChapter: a.End[0], EndVerse: a.End[1]}
+}
+
+// covers reports whether the note is on ref, a verse in KJV numbering
+func (a annotation) covers(ref bible.VerseRef) bool {
+	at := [2]int{ref.Chapter, ref.Verse}
+	return ref.Book == a.Book && !versesBefore(at, a.Start) && !versesBefore(a.End, at)
+}
+
+// versesBefore reports whether the chapter and verse a come before b
+func versesBefore(a, b [2]int) bool {
+	return a[0] < b[0] || (a[0] == b[0] && a[1] < b[1])
+}
+
+// notesPath is the file the notes are kept in
+func notesPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "notes.json"), nil
+}
+
+// loadNotes reads the notes at path; there are none until the file is written
+func loadNotes(path string) ([]annotation, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var notes []annotation
+	if err := json.Unmarshal(data, &notes); err != nil {
+		return nil, fmt.Errorf("notes file %s: %w", path, err)
+	}
+	return notes, nil
+}
+
+// saveNotes writes notes to path, making its directory if need be
+func saveNotes(path string, notes []annotation) error {
+	data, err := json.MarshalIndent(notes, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// completeNoteCommands completes the word after note
+func completeNoteCommands(r *repl, args string) []string {
+	if strings.Contains(args, " ") {
+		return nil
+	}
+	return completeWords(args, noteCommands)
+}
+
+// kjvVerses returns the first and last verses of passage, which is
+// numbered like the prompts, in KJV numbering
+func (r *repl) kjvVerses(passage bible.Passage) (bible.VerseRef, bible.VerseRef, error) {
+	var kjvRefs []bible.VerseRef
+	for _, ref := range passage.VerseRefs(r.ropes) {
+		kjvRefs = append(kjvRefs, r.scheme.ToKJV(ref)...)
+	}
+	if len(kjvRefs) == 0 {
+		return bible.VerseRef{}, bible.VerseRef{}, fmt.Errorf("none of the translations shown have %s", passage)
+	}
+	return kjvRefs[0], kjvRefs[len(kjvRefs)-1], nil
+}
+
+// notesOn returns the function that gives the notes to show under a verse
+// numbered like the prompts, each labelled with the verses it is on, in
+// KJV numbering.  Each note is shown once, under the first verse it is on,
+// however many of its verses are shown.
+func (r *repl) notesOn(w io.Writer) func(ref bible.VerseRef) []string {
+	notes, err := loadNotes(r.notesPath)
+	if r.notesPath == "" || err != nil || len(notes) == 0 {
+		return nil
+	}
+	s := r.styleFor(w)
+	shown := make([]bool, len(notes))
+	return func(ref bible.VerseRef) []string {
+		var lines []string
+		for _, kjvRef := range r.scheme.ToKJV(ref) {
+			for i, note := range notes {
+				if shown[i] || !note.covers(kjvRef) {
+					continue
+				}
+				shown[i] = true
+				label := fmt.Sprintf("Note on %s:", note.passage())
+				words := strings.Fields(note.Text)
+				styled := slices.Clone(words)
+				if len(note.Tags) > 0 {
+					tags := formatTags(note.Tags)
+					words, styled = append(words, tags), append(styled, s.note(tags))
+				}
+				lines = append(lines, wrapWords(words, styled, r.width, "  "+label+" ", "  "+s.label(label)+" ")...)
+			}
+		}
+		return lines
+	}
+}
+
+func (r *repl) noteCommand(args string) (bool, error) {
+	if r.notesPath == "" {
+		return false, fmt.Errorf("notes cannot be kept, as there is no config directory")
+	}
+	notes, err := loadNotes(r.notesPath)
+	if err != nil {
+		return false, err
+	}
+	subcommand, args, _ := strings.Cut(strings.TrimSpace(args), " ")
+	args = strings.TrimSpace(args)
+	switch subcommand {
+	case "add":
+		return false, r.noteAdd(notes, args)
+	case "list":
+		return false, r.noteList(notes, args)
+	case "search":
+		terms := bible.SearchTerms(args)
+		if len(terms) == 0 {
+			return false, fmt.Errorf("give some words to search the notes for, like 'note search wedding'")
+		}
+		var found []int
+		for i, note := range notes {
+			text := strings.ToLower(note.passage().String() + " " + note.Text + " " + formatTags(note.Tags))
+			if !slices.ContainsFunc(terms, func(term string) bool { return !strings.Contains(text, term) }) {
+				found = append(found, i)
+			}
+		}
+		if len(found) == 0 {
+			fmt.Fprintf(r.out, "No notes have %s\n", args)
+			return false, nil
+		}
+		return false, r.writeNotes(notes, found)
+	case "rm":
+		number, err := strconv.Atoi(args)
+		if err != nil || number < 1 || number > len(notes) {
+			return false, fmt.Errorf("%q is not the number of a note, 'note list' numbers them", args)
+		}
+		fmt.Fprintf(r.out, "Removed the note on %s\n", notes[number-1].passage())
+		return false, saveNotes(r.notesPath, slices.Delete(notes, number-1, number))
+	case "export":
+		return false, r.noteExport(notes, args)
+	}
+	return false, fmt.Errorf("unknown note command %q, use one of %v", subcommand, noteCommands)
+}
+
+// noteAdd notes the text in args on the reference it starts with, or on
+// the passage shown last, with the #tags in it
+func (r *repl) noteAdd(notes []annotation, args string) error {
+	tags, words := splitTags(strings.Fields(args))
+	passage, words, err := r.leadingPassage(words)
+	if err != nil {
+		return err
+	}
+	if len(words) == 0 {
+		return fmt.Errorf("give the text of the note, like 'note add John 3:16 #love read at weddings'")
+	}
+	start, end, err := r.kjvVerses(passage)
+	if err != nil {
+		return err
+	}
+	note := annotation{
+		Book:  start.Book,
+		Start: [2]int{start.Chapter, start.Verse},
+		End:   [2]int{end.Chapter, end.Verse},
+		Text:  strings.Join(words, " "),
+		Tags:  tags,
+		Added: time.Now().UTC().Truncate(time.Second),
+	}
+	notes = append(notes, note)
+	fmt.Fprintf(r.out, "Noted %s as %d\n", note.passage(), len(notes))
+	return saveNotes(r.notesPath, notes)
+}
+
+// noteList lists every note, or those on the reference in args or with
+// the #tags in it
+func (r *repl) noteList(notes []annotation, args string) error {
+	tags, words := splitTags(strings.Fields(args))
+	var on func(annotation) bool
+	if len(words) > 0 {
+		passage, err := r.passageArg(strings.Join(words, " "))
+		if err != nil {
+			return err
+		}
+		start, end, err := r.kjvVerses(passage)
+		if err != nil {
+			return err
+		}
+		// the notes on any verse of the passage
+		on = func(note annotation) bool {
+			return note.Book == start.Book && !versesBefore(note.End, [2]int{start.Chapter, start.Verse}) && !versesBefore([2]int{end.Chapter, end.Verse}, note.Start)
+		}
+	}
+	var listed []int
+	for i, note := range notes {
+		if hasTags(note.Tags, tags) && (on == nil || on(note)) {
+			listed = append(listed, i)
+		}
+	}
+	if len(listed) == 0 {
+		if len(notes) == 0 {
+			fmt.Fprintln(r.out, "There are no notes yet; 'note add John 3:16 some words' makes one")
+		} else {
+			fmt.Fprintf(r.out, "No notes are on %s\n", args)
+		}
+		return nil
+	}
+	return r.writeNotes(notes, listed)
+}
+
+// writeNotes lists the notes numbered in listed, with their numbers
+func (r *repl) writeNotes(notes []annotation, listed []int) error {
+	w := tabwriter.NewWriter(r.out, 0, 4, 2, ' ', 0)
+	for _, i := range listed {
+		note := notes[i]
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", i+1, r.style.heading(note.passage().String()), note.Added.Local().Format(time.DateOnly), strings.TrimSpace(note.Text+" "+r.style.note(formatTags(note.Tags))))
+	}
+	return w.Flush()
+}
+
+// noteExport writes the notes, or those with the #tags after the file
+// name, to a Markdown file, in the order of the books, each with the text
+// of the first translation shown
+func (r *repl) noteExport(notes []annotation, args string) error {
+	path, rest, _ := strings.Cut(args, " ")
+	tags, others := splitTags(strings.Fields(rest))
+	if path == "" || len(others) > 0 {
+		return fmt.Errorf("give a file to export to and any tags, like 'note export notes.md #love'")
+	}
+	var exported []annotation
+	for _, note := range notes {
+		if hasTags(note.Tags, tags) {
+			exported = append(exported, note)
+		}
+	}
+	order := make(map[string]int)
+	for i, book := range r.canonBooks {
+		order[book] = i
+	}
+	slices.SortStableFunc(exported, func(a, b annotation) int {
+		if a.Book != b.Book {
+			return order[a.Book] - order[b.Book]
+		}
+		if versesBefore(a.Start, b.Start) {
+			return -1
+		}
+		if versesBefore(b.Start, a.Start) {
+			return 1
+		}
+		return 0
+	})
+
+	var b strings.Builder
+	b.WriteString("# Notes\n")
+	for _, note := range exported {
+		passage := note.passage()
+		fmt.Fprintf(&b, "\n## %s\n\n", passage)
+		if len(r.translations) > 0 {
+			translation := r.translations[0]
+			var verses []string
+			for _, ref := range passage.VerseRefs(schemeRopes(r.translations, bible.KJV)) {
+				if text, _, found := translation.LookupVerse(ref, bible.KJV); found {
+					if !passage.IsSingleVerse() {
+						text = fmt.Sprintf("<sup>%d</sup> %s", ref.Verse, text)
+					}
+					verses = append(verses, text)
+				}
+			}
+			if len(verses) > 0 {
+				fmt.Fprintf(&b, "> %s\n>\n> — %s\n\n", strings.Join(verses, " "), translation.Title)
+			}
+		}
+		fmt.Fprintf(&b, "%s\n\n", note.Text)
+		fmt.Fprintf(&b, "*%s*", note.Added.Local().Format(time.DateOnly))
+		if len(note.Tags) > 0 {
+			fmt.Fprintf(&b, " %s", formatTags(note.Tags))
+		}
+		b.WriteString("\n")
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(r.out, "Saved %d notes to %s\n", len(exported), path)
+	return nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/botanyhelp/goBibleVerseComparer/bible"
+)
+
+// formats are the values accepted by the -format flag.  Text is for
+// people to read; the others write one bible.VerseResult per verse per
+// translation, for other programs to read.
+var formats []string = []string{"text", "json", "jsonl", "tsv", "markdown"}
+
+// resultFields are the fields of a bible.VerseResult, in the order tsv
+// and markdown write them, named as in its JSON form
+var resultFields []string = []string{"reference", "translation", "title", "text", "found"}
+
+// resultWriter writes verse results in one of the structured formats.
+// Every lookup is written as it is made, so batch mode can be read as it
+// goes: json writes an array for each lookup, jsonl a line for each result,
+// tsv one header and then a row for each result, and markdown a table for
+// each lookup.
+type resultWriter struct {
+	w      io.Writer
+	format string
+	// wroteHeader is set once the tsv header is written
+	wroteHeader bool
+}
+
+// passageResults compares every verse of refs, which are numbered in
+// scheme, across translations
+func passageResults(refs []bible.VerseRef, translations []*bible.Translation, scheme *bible.Versification) []bible.VerseResult {
+	results := []bible.VerseResult{}
+	for _, ref := range refs {
+		results = append(results, bible.Compare(translations, ref, scheme)...)
+	}
+	return results
+}
+
+// searchResults turns the hits of a search into verse results
+func searchResults(hits []bible.SearchHit) []bible.VerseResult {
+	results := []bible.VerseResult{}
+	for _, hit := range hits {
+		results = append(results, bible.VerseResult{
+			Reference:   hit.Ref.String(),
+			Translation: hit.Translation.Code,
+			Title:       hit.Translation.Title,
+			Text:        hit.Text,
+			Found:       true,
+			HasBook:     true,
+		})
+	}
+	return results
+}
+
+// resultRow is result as the fields tsv and markdown write, in the order
+// of resultFields.  Runs of spaces, tabs and line breaks become one space,
+// so a field never spills into the next one or onto the next row.
+func resultRow(result bible.VerseResult) []string {
+	fields := []string{result.Reference, result.Translation, result.Title, result.Text, fmt.Sprint(result.Found)}
+	for i, field := range fields {
+		fields[i] = strings.Join(strings.Fields(field), " ")
+	}
+	return fields
+}
+
+// write writes the results of one lookup
+func (rw *resultWriter) write(results []bible.VerseResult) error {
+	switch rw.format {
+	case "json":
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(rw.w, "%s\n", data)
+		return err
+	case "jsonl":
+		encoder := json.NewEncoder(rw.w)
+		for _, result := range results {
+			if err := encoder.Encode(result); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "tsv":
+		if !rw.wroteHeader {
+			fmt.Fprintln(rw.w, strings.Join(resultFields, "\t"))
+			rw.wroteHeader = true
+		}
+		for _, result := range results {
+			if _, err := fmt.Fprintln(rw.w, strings.Join(resultRow(result), "\t")); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "markdown":
+		fmt.Fprintf(rw.w, "| %s |\n|%s\n", strings.Join(resultFields, " | "), strings.Repeat(" --- |", len(resultFields)))
+		for _, result := range results {
+			fields := resultRow(result)
+			for i, field := range fields {
+				fields[i] = strings.ReplaceAll(field, "|", `\|`)
+			}
+			if _, err := fmt.Fprintf(rw.w, "| %s |\n", strings.Join(fields, " | ")); err != nil {
+				return err
+			}
+		}
+		_, err := fmt.Fprintln(rw.w)
+		return err
+	}
+	return fmt.Errorf("unknown format %q, choose one of %v", rw.format, formats)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/botanyhelp/goBibleVerseComparer/bible"
+)
+
+func TestResultWriterGolden(t *testing.T) {
+	translations := loadFixtures(t)
+	refs := []bible.VerseRef{{Book: "John", Chapter: 3, Verse: 16}, {Book: "Malachi", Chapter: 4, Verse: 5}}
+	results := passageResults(refs, translations, bible.KJV)
+	for _, test := range []struct{ format, golden string }{
+		{"json", "results.json"},
+		{"jsonl", "results.jsonl"},
+		{"tsv", "results.tsv"},
+		{"markdown", "results.md"},
+	} {
+		t.Run(test.format, func(t *testing.T) {
+			var out bytes.Buffer
+			rw := &resultWriter{w: &out, format: test.format}
+			// a second lookup shows what is repeated for each one
+			for _, lookup := range [][]bible.VerseResult{results, results[:1]} {
+				if err := rw.write(lookup); err != nil {
+					t.Fatal(err)
+				}
+			}
+			checkGolden(t, test.golden, out.Bytes())
+		})
+	}
+}
+
+func TestResultWriterSchema(t *testing.T) {
+	var out bytes.Buffer
+	rw := &resultWriter{w: &out, format: "jsonl"}
+	result := bible.VerseResult{Reference: "John 3:16", Translation: "kjv", Title: "King James Bible", Text: "For God", Found: true, HasBook: true, Resolved: []bible.VerseRef{{Book: "John", Chapter: 3, Verse: 16}}}
+	if err := rw.write([]bible.VerseResult{result}); err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(out.Bytes(), &fields); err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	if len(keys) != len(resultFields) {
+		t.Errorf("jsonl has the keys %v, want %v", keys, resultFields)
+	}
+	for _, field := range resultFields {
+		if _, ok := fields[field]; !ok {
+			t.Errorf("jsonl has no %q key: %s", field, out.String())
+		}
+	}
+
+	out.Reset()
+	rw = &resultWriter{w: &out, format: "tsv"}
+	result.Text = "text with\ta tab\nand a line break"
+	if err := rw.write([]bible.VerseResult{result}); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n"); len(lines) != 2 || strings.Count(lines[1], "\t") != len(resultFields)-1 {
+		t.Errorf("tsv row is not one line of %d fields:\n%s", len(resultFields), out.String())
+	}
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/botanyhelp/goBibleVerseComparer/bible"
+)
+
+// planCommands are the words that may follow plan
+var planCommands []string = []string{"today", "day", "done", "undo", "start", "status", "list", "stop"}
+
+// planState is the reading plan being followed, as the state file keeps it
+type planState struct {
+	// Plan is the name of a built-in plan or the path of a JSON plan
+	Plan string `json:"plan"`
+	// Start is the day the plan began, like 2026-01-01
+	Start string `json:"start"`
+	// Done are the days read, numbered from 1
+	Done []int `json:"done,omitempty"`
+}
+
+// planPath is the file the reading plan being followed is kept in
+func planPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "plan.json"), nil
+}
+
+// loadPlanState reads the plan being followed from path, or nil when
+// none has been started
+func loadPlanState(path string) (*planState, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state planState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("reading plan file %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+// savePlanState writes state to path, making its directory if need be
+func savePlanState(path string, state *planState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// today is the date now, at midnight UTC so days can be counted
+func (r *repl) today() time.Time {
+	now := time.Now()
+	if r.now != nil {
+		now = r.now()
+	}
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// dayOf is the day of the plan that date is, 1 on the day it started
+func (state *planState) dayOf(date time.Time) (int, error) {
+	start, err := time.Parse(time.DateOnly, state.Start)
+	if err != nil {
+		return 0, fmt.Errorf("the reading plan has a bad start date: %w", err)
+	}
+	return int(date.Sub(start).Hours()/24) + 1, nil
+}
+
+// loadPlan returns the built-in plan called name, or reads the JSON plan
+// at the path name
+func (r *repl) loadPlan(name string) (*bible.ReadingPlan, error) {
+	if slices.Contains(bible.PlanNames, name) {
+		return bible.BuiltinPlan(name)
+	}
+	data, err := os.ReadFile(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("there is no reading plan %q, choose one of %v or a JSON file", name, bible.PlanNames)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return bible.ParsePlan(name, data, r.canonBooks)
+}
+
+// completePlanCommands completes the word after plan, and the plan after
+// plan start
+func completePlanCommands(r *repl, args string) []string {
+	if rest, ok := strings.CutPrefix(args, "start "); ok && !strings.Contains(rest, " ") {
+		return completeLastWord(args, bible.PlanNames)
+	}
+	if strings.Contains(args, " ") {
+		return nil
+	}
+	return completeWords(args, planCommands)
+}
+
+func (r *repl) planCommand(args string) (bool, error) {
+	if r.planPath == "" {
+		return false, fmt.Errorf("reading plans cannot be followed, as there is no config directory")
+	}
+	subcommand, args, _ := strings.Cut(strings.TrimSpace(args), " ")
+	args = strings.TrimSpace(args)
+	switch subcommand {
+	case "list":
+		w := tabwriter.NewWriter(r.out, 0, 4, 2, ' ', 0)
+		for _, name := range bible.PlanNames {
+			plan, err := bible.BuiltinPlan(name)
+			if err != nil {
+				return false, err
+			}
+			fmt.Fprintf(w, "%s\t%d days\t%s\n", name, len(plan.Days), plan.Title)
+		}
+		return false, w.Flush()
+	case "start":
+		return false, r.planStart(args)
+	}
+
+	state, err := loadPlanState(r.planPath)
+	if err != nil {
+		return false, err
+	}
+	if state == nil {
+		return false, fmt.Errorf("no reading plan has been started; 'plan list' lists them and 'plan start year' starts one")
+	}
+	plan, err := r.loadPlan(state.Plan)
+	if err != nil {
+		return false, err
+	}
+	today, err := state.dayOf(r.today())
+	if err != nil {
+		return false, err
+	}
+	switch subcommand {
+	case "", "today":
+		return r.planDay(plan, state, today)
+	case "day", "done", "undo":
+		day := today
+		if args != "" {
+			if day, err = strconv.Atoi(args); err != nil {
+				return false, fmt.Errorf("%q is not a day of the plan", args)
+			}
+		}
+		if day < 1 || day > len(plan.Days) {
+			return false, fmt.Errorf("day %d is not in %s, which has days 1 to %d", day, plan.Title, len(plan.Days))
+		}
+		switch subcommand {
+		case "day":
+			return r.planDay(plan, state, day)
+		case "done":
+			if !slices.Contains(state.Done, day) {
+				state.Done = append(state.Done, day)
+				slices.Sort(state.Done)
+			}
+			fmt.Fprintf(r.out, "Day %d is read, %d of %d days\n", day, len(state.Done), len(plan.Days))
+		case "undo":
+			state.Done = slices.DeleteFunc(state.Done, func(done int) bool { return done == day })
+			fmt.Fprintf(r.out, "Day %d is not read\n", day)
+		}
+		return false, savePlanState(r.planPath, state)
+	case "status":
+		r.planStatus(plan, state, today)
+		return false, nil
+	case "stop":
+		fmt.Fprintf(r.out, "Stopped %s, with %d of %d days read\n", plan.Title, len(state.Done), len(plan.Days))
+		return false, os.Remove(r.planPath)
+	}
+	return false, fmt.Errorf("unknown plan command %q, use one of %v", subcommand, planCommands)
+}
+
+// planStart starts following the plan named first in args, from the date
+// after it or from today
+func (r *repl) planStart(args string) error {
+	fields := strings.Fields(args)
+	if len(fields) == 0 || len(fields) > 2 {
+		return fmt.Errorf("give a plan and, if it did not start today, the day it started, like 'plan start year 2026-01-01'")
+	}
+	plan, err := r.loadPlan(fields[0])
+	if err != nil {
+		return err
+	}
+	start := r.today()
+	if len(fields) == 2 {
+		if start, err = time.Parse(time.DateOnly, fields[1]); err != nil {
+			return fmt.Errorf("%q is not a date like 2026-01-01", fields[1])
+		}
+	}
+	// a JSON plan is kept by its full path, so plan works from any directory
+	name := fields[0]
+	if !slices.Contains(bible.PlanNames, name) {
+		if name, err = filepath.Abs(name); err != nil {
+			return err
+		}
+	}
+	state := &planState{Plan: name, Start: start.Format(time.DateOnly)}
+	if err := savePlanState(r.planPath, state); err != nil {
+		return err
+	}
+	fmt.Fprintf(r.out, "Started %s on %s; 'plan' shows the reading for today\n", plan.Title, state.Start)
+	return nil
+}
+
+// planDay shows the passages of day of plan
+func (r *repl) planDay(plan *bible.ReadingPlan, state *planState, day int) (bool, error) {
+	if day < 1 {
+		fmt.Fprintf(r.out, "%s starts on %s\n", plan.Title, state.Start)
+		return false, nil
+	}
+	if day > len(plan.Days) {
+		fmt.Fprintf(r.out, "%s is over, with %d of %d days read; 'plan status' lists the days not read\n", plan.Title, len(state.Done), len(plan.Days))
+		return false, nil
+	}
+	var references []string
+	for _, passage := range plan.Days[day-1] {
+		references = append(references, passage.String())
+	}
+	read := ""
+	if slices.Contains(state.Done, day) {
+		read = ", read"
+	}
+	if r.format == "text" {
+		fmt.Fprintln(r.out, r.style.heading(fmt.Sprintf("Day %d of %d%s: %s", day, len(plan.Days), read, strings.Join(references, "; "))))
+		fmt.Fprintln(r.out)
+	}
+	for i, passage := range plan.Days[day-1] {
+		if i > 0 && r.format == "text" {
+			fmt.Fprintln(r.out)
+		}
+		r.showPassage(passage)
+	}
+	if read == "" && r.format == "text" {
+		fmt.Fprintf(r.out, "\n'plan done %d' marks this day read\n", day)
+	}
+	return true, nil
+}
+
+// planStatus says how far through plan the reader is, and which of the
+// days up to today are not read yet
+func (r *repl) planStatus(plan *bible.ReadingPlan, state *planState, today int) {
+	fmt.Fprintf(r.out, "%s, started %s\n", plan.Title, state.Start)
+	var behind []int
+	for day := 1; day < min(today, len(plan.Days)+1); day++ {
+		if !slices.Contains(state.Done, day) {
+			behind = append(behind, day)
+		}
+	}
+	switch {
+	case today < 1:
+		fmt.Fprintf(r.out, "It starts in %d days, and has %d days\n", 1-today, len(plan.Days))
+	case today > len(plan.Days):
+		fmt.Fprintf(r.out, "It is over; %d of %d days are read\n", len(state.Done), len(plan.Days))
+	default:
+		fmt.Fprintf(r.out, "Today is day %d of %d; %d days are read\n", today, len(plan.Days), len(state.Done))
+	}
+	if len(behind) > 0 {
+		fmt.Fprintf(r.out, "Days not read yet: %s\n", bible.FormatNumberRanges(behind))
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/botanyhelp/goBibleVerseComparer/bible"
+)
+
+// testaments are the words random takes for the books of each testament
+var testaments []string = []string{"ot", "nt"}
+
+// completeRandomBooks completes any and the testaments after random, and
+// the book after those
+func completeRandomBooks(r *repl, args string) []string {
+	if rest, ok := strings.CutPrefix(args, "any "); ok {
+		var completions []string
+		for _, word := range completeWords(rest, append(slices.Clone(testaments), r.books...)) {
+			completions = append(completions, "any "+word)
+		}
+		return completions
+	}
+	return completeWords(args, append(append([]string{"any"}, testaments...), r.books...))
+}
+
+// votdCommand shows the verse of the day, or of the date in args, across
+// the translations shown
+func (r *repl) votdCommand(args string) (bool, error) {
+	date := r.today()
+	if args = strings.TrimSpace(args); args != "" {
+		var err error
+		if date, err = time.Parse(time.DateOnly, args); err != nil {
+			return false, fmt.Errorf("%q is not a date like 2026-12-25", args)
+		}
+	}
+	curated := r.curatedVerses(bible.AllVerses(r.ropes, r.books))
+	if len(curated) == 0 {
+		return false, fmt.Errorf("none of the loaded texts have any of the verses of the day")
+	}
+	ref := bible.VerseOfTheDay(date, curated)
+	if r.format == "text" {
+		fmt.Fprintln(r.out, r.style.note("Verse of the day, "+date.Format("Monday 2 January 2006")))
+	}
+	r.showPassage(bible.VersePassage(ref))
+	return true, nil
+}
+
+// randomCommand shows a verse picked at random from the curated verses,
+// or from every verse when args starts with any, in the book or testament
+// in args
+func (r *repl) randomCommand(args string) (bool, error) {
+	words := strings.Fields(args)
+	anyVerse := len(words) > 0 && words[0] == "any"
+	if anyVerse {
+		words = words[1:]
+	}
+	books := r.books
+	switch where := strings.Join(words, " "); where {
+	case "":
+	case "ot", "nt":
+		books = slices.DeleteFunc(slices.Clone(r.books), func(book string) bool { return bible.IsNewTestament(book) != (where == "nt") })
+	default:
+		book, err := bible.ResolveBookName(where, r.books)
+		if err != nil {
+			return false, err
+		}
+		books = []string{book}
+	}
+
+	verses := bible.AllVerses(r.ropes, books)
+	if len(verses) == 0 {
+		return false, fmt.Errorf("none of the loaded texts have verses in %s", strings.Join(words, " "))
+	}
+	if !anyVerse {
+		// every verse when the loaded texts have none of the curated ones
+		if curated := r.curatedVerses(verses); len(curated) > 0 {
+			verses = curated
+		}
+	}
+	r.showPassage(bible.VersePassage(verses[r.intn(len(verses))]))
+	return true, nil
+}
+
+// curatedVerses returns the curated verses that are among verses,
+// numbered like the prompts rather than like the KJV
+func (r *repl) curatedVerses(verses []bible.VerseRef) []bible.VerseRef {
+	var curated []bible.VerseRef
+	for _, kjvRef := range bible.CuratedVerses {
+		for _, ref := range r.scheme.FromKJV(kjvRef) {
+			if slices.Contains(verses, ref) && !slices.Contains(curated, ref) {
+				curated = append(curated, ref)
+			}
+		}
+	}
+	return curated
+}
+
+// intn returns a number from 0 up to n, picked at random
+func (r *repl) intn(n int) int {
+	if r.rng != nil {
+		return r.rng.Intn(n)
+	}
+	return rand.Intn(n)
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/botanyhelp/goBibleVerseComparer/bible"
+)
+
+// layouts are the values accepted by the -layout flag
+var layouts []string = []string{"interleaved", "parallel"}
+
+// wrapText breaks text into lines no wider than width, starting the first
+// line with firstPrefix and every later line with enough spaces to line up
+// under the text, so wrapped lines hang below the prefix.  Words longer
+// than a line are left whole.
+func wrapText(text string, width int, firstPrefix string) []string {
+	words := strings.Fields(text)
+	return wrapWords(words, words, width, firstPrefix, firstPrefix)
+}
+
+// wrapWords is wrapText for words that may be styled.  Lines are broken by
+// the width of words, and styled, which has a word for each of them, is
+// what is written, starting with styledPrefix, the styled firstPrefix.
+func wrapWords(words, styled []string, width int, firstPrefix, styledPrefix string) []string {
+	indent := strings.Repeat(" ", len([]rune(firstPrefix)))
+	var lines []string
+	line, lineWidth := styledPrefix, len([]rune(firstPrefix))
+	lineHasWord := false
+	for i, word := range words {
+		if lineHasWord && lineWidth+1+len([]rune(word)) > width {
+			lines = append(lines, line)
+			line, lineWidth, lineHasWord = indent, len(indent), false
+		}
+		if lineHasWord {
+			line += " "
+			lineWidth++
+		}
+		line += styled[i]
+		lineWidth += len([]rune(word))
+		lineHasWord = true
+	}
+	return append(lines, line)
+}
+
+// verseText is one translation's verse as renderVerses shows it
+type verseText struct {
+	label string
+	// text is the verse, empty when the translation does not have it
+	text string
+	// note follows the text, or stands in for it, like "[omitted in this
+	// translation]"
+	note string
+}
+
+// renderVerses lays out one verse from several translations, each after
+// its label and wrapped with a hanging indent.  The labels are padded to
+// the same width so the texts line up, unless that would leave them less
+// than half of width, when each text goes below its label instead.  With
+// color, the words that differ from the first text are highlighted.
+func renderVerses(texts []verseText, width int, s style) []string {
+	labelWidth := 0
+	base := ""
+	for _, text := range texts {
+		labelWidth = max(labelWidth, len([]rune(text.label)))
+		if base == "" {
+			base = text.text
+		}
+	}
+	below := 2+labelWidth+2 > width/2
+	var lines []string
+	for _, text := range texts {
+		prefix, styledPrefix := "  "+text.label+":", "  "+s.label(text.label+":")
+		if below {
+			lines = append(lines, styledPrefix)
+			prefix, styledPrefix = "    ", "    "
+		} else {
+			padding := strings.Repeat(" ", labelWidth-len([]rune(text.label))+1)
+			prefix, styledPrefix = prefix+padding, styledPrefix+padding
+		}
+		words, styled := s.highlightWords(base, text.text)
+		if text.note != "" {
+			words, styled = append(words, text.note), append(styled, s.note(text.note))
+		}
+		lines = append(lines, wrapWords(words, styled, width, prefix, styledPrefix)...)
+	}
+	return lines
+}
+
+// renderPassage lays out the verses of refs from every translation.
+// Interleaved shows each verse from all translations before moving to
+// the next verse; parallel shows the whole passage from one translation
+// and then the next.  notes, when not nil, gives the lines of the notes
+// on a verse, which interleaved shows under it and parallel after the
+// whole passage.
+func renderPassage(passage bible.Passage, refs []bible.VerseRef, translations []*bible.Translation, scheme *bible.Versification, layout string, width int, s style, notes func(bible.VerseRef) []string) []string {
+	lines := []string{s.heading(passage.String())}
+	if layout == "parallel" {
+		// the words of each verse are compared with the first translation that has it
+		bases := make(map[bible.VerseRef]string)
+		if s.color {
+			for _, ref := range refs {
+				for _, translation := range translations {
+					if content, _, found := translation.LookupVerse(ref, scheme); found {
+						bases[ref] = content
+						break
+					}
+				}
+			}
+		}
+		for _, translation := range translations {
+			lines = append(lines, "", s.label("== "+translation.Title+" =="))
+			lastChapter := 0
+			for _, ref := range refs {
+				if ref.Chapter != lastChapter && passage.StartChapter != passage.EndChapter {
+					lines = append(lines, s.heading(fmt.Sprintf("Chapter %d", ref.Chapter)))
+				}
+				lastChapter = ref.Chapter
+				number := fmt.Sprintf("%3d ", ref.Verse)
+				content, _, found := translation.LookupVerse(ref, scheme)
+				if !found {
+					lines = append(lines, number+s.note("[omitted in this translation]"))
+					continue
+				}
+				words, styled := s.highlightWords(bases[ref], content)
+				lines = append(lines, wrapWords(words, styled, width, number, number)...)
+			}
+		}
+		if notes != nil {
+			var noteLines []string
+			for _, ref := range refs {
+				noteLines = append(noteLines, notes(ref)...)
+			}
+			if len(noteLines) > 0 {
+				lines = append(append(lines, "", s.label("== Notes ==")), noteLines...)
+			}
+		}
+		return lines
+	}
+	for _, ref := range refs {
+		lines = append(lines, "", s.heading(fmt.Sprintf("%d:%d", ref.Chapter, ref.Verse)))
+		var texts []verseText
+		for _, translation := range translations {
+			text := verseText{label: translation.Title}
+			if content, _, found := translation.LookupVerse(ref, scheme); found {
+				text.text = content
+			} else {
+				text.note = "[omitted in this translation]"
+			}
+			texts = append(texts, text)
+		}
+		lines = append(lines, renderVerses(texts, width, s)...)
+		if notes != nil {
+			lines = append(lines, notes(ref)...)
+		}
+	}
+	return lines
+}
+
+// isTerminal reports whether f is a terminal rather than a pipe or file
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// terminalSize returns the width and height of the terminal from the
+// COLUMNS and LINES environment variables, or else from the terminal on
+// stdout, or 80x24 when neither says
+func terminalSize() (int, int) {
+	width, height := 80, 24
+	if columns, rows, ok := windowSize(os.Stdout.Fd()); ok {
+		width, height = columns, rows
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		width = columns
+	}
+	if rows, err := strconv.Atoi(os.Getenv("LINES")); err == nil && rows > 0 {
+		height = rows
+	}
+	return width, height
+}
+
+// pageLines writes lines to w a screenful at a time, waiting for Enter
+// from reader between screens.  Typing q stops the output.  A height of
+// 0 or less writes everything at once.
+func pageLines(w io.Writer, lines []string, height int, reader *bufio.Reader) {
+	for i, line := range lines {
+		if height > 1 && i > 0 && i%(height-1) == 0 {
+			fmt.Fprint(w, "-- More -- (Enter for more, q to stop) ")
+			answer, err := reader.ReadString('\n')
+			if err != nil || strings.TrimSpace(answer) == "q" {
+				return
+			}
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+// printCoverageReport writes the report in a form meant for people to read
+func printCoverageReport(w io.Writer, report []bible.Coverage, reference *bible.Translation) {
+	fmt.Fprintf(w, "Verse coverage compared with %s, in KJV numbering\n", reference.Title)
+	for _, coverage := range report {
+		fmt.Fprintf(w, "\n%s: %d verses, %d missing, %d extra\n", coverage.Title, coverage.Verses, len(coverage.Missing), len(coverage.Extra))
+		if len(coverage.Missing) > 0 {
+			fmt.Fprintf(w, "  missing: %s\n", bible.FormatVerseRefs(coverage.Missing))
+		}
+		if len(coverage.Extra) > 0 {
+			fmt.Fprintf(w, "  extra: %s\n", bible.FormatVerseRefs(coverage.Extra))
+		}
+	}
+}
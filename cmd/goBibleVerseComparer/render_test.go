@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/botanyhelp/goBibleVerseComparer/bible"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata with the output the tests get")
+
+// loadFixtures loads the small bibles the bible package tests with
+func loadFixtures(t *testing.T) []*bible.Translation {
+	t.Helper()
+	var translations []*bible.Translation
+	for _, fixture := range []struct{ name, title string }{
+		{"kjv.txt", "King James Bible"}, {"jps.txt", "JPS Tanakh 1917"}, {"drb.txt", "Douay-Rheims Bible"},
+	} {
+		path := filepath.Join("..", "..", "bible", "testdata", fixture.name)
+		translation, _, err := loadBibleFile(path, fixture.title, "off")
+		if err != nil {
+			t.Fatal(err)
+		}
+		translation.Code = bible.TranslationCode(path)
+		translations = append(translations, translation)
+	}
+	return translations
+}
+
+// checkGolden compares got with testdata/name, or rewrites that file with -update
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s, run go test -update if the change is right\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+func TestPrintVerseGolden(t *testing.T) {
+	translations := loadFixtures(t)
+	var out bytes.Buffer
+	for _, ref := range []bible.VerseRef{
+		{Book: "Genesis", Chapter: 1, Verse: 1},
+		{Book: "Psalm", Chapter: 51, Verse: 1},
+		{Book: "Malachi", Chapter: 4, Verse: 5},
+		{Book: "John", Chapter: 3, Verse: 16},
+		{Book: "3 John", Chapter: 1, Verse: 14},
+		{Book: "Genesis", Chapter: 1, Verse: 4},
+	} {
+		printVerse(&out, translations, ref, bible.KJV, 60, style{})
+	}
+	checkGolden(t, "compare.golden", out.Bytes())
+}
+
+func TestRenderPassageGolden(t *testing.T) {
+	translations := loadFixtures(t)
+	books := []string{"Genesis", "Psalm", "Malachi"}
+	for _, test := range []struct{ reference, layout, golden string }{
+		{"Gen 1:1-3", "interleaved", "passage-interleaved.golden"},
+		{"Gen 1:1-3", "parallel", "passage-parallel.golden"},
+		{"Mal 4", "interleaved", "passage-chapter.golden"},
+	} {
+		passage, err := bible.ParseReference(test.reference, books)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines := renderPassage(passage, passage.VerseRefs(bible.Ropes(translations)), translations, bible.KJV, test.layout, 60, style{}, nil)
+		checkGolden(t, test.golden, []byte(strings.Join(lines, "\n")+"\n"))
+	}
+}
+
+func TestRenderPassageColor(t *testing.T) {
+	translations := loadFixtures(t)
+	passage, err := bible.ParseReference("Gen 1:2-3", []string{"Genesis"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	escapes := regexp.MustCompile("\x1b\\[[0-9;]*m")
+	for _, layout := range layouts {
+		lines := renderPassage(passage, passage.VerseRefs(bible.Ropes(translations)), translations, bible.KJV, layout, 60, style{color: true}, nil)
+		plain := renderPassage(passage, passage.VerseRefs(bible.Ropes(translations)), translations, bible.KJV, layout, 60, style{}, nil)
+		// color changes how the lines look, never where they break
+		for i, line := range lines {
+			if stripped := escapes.ReplaceAllString(line, ""); i >= len(plain) || stripped != plain[i] {
+				t.Errorf("%s line %d is %q without its color, want %q", layout, i, stripped, plain[min(i, len(plain)-1)])
+			}
+		}
+		if len(lines) != len(plain) {
+			t.Errorf("%s has %d lines with color and %d without", layout, len(lines), len(plain))
+		}
+		checkGolden(t, "passage-"+layout+"-color.golden", []byte(strings.Join(lines, "\n")+"\n"))
+	}
+}
+
+func TestRenderVerses(t *testing.T) {
+	texts := []verseText{
+		{label: "King James Bible", text: "And God said, Let there be light: and there was light."},
+		{label: "Douay-Rheims Bible", text: "And God said: Be light made. And light was made."},
+		{label: "JPS Tanakh 1917", note: "[omitted in this translation]"},
+	}
+	want := []string{
+		"  King James Bible:   And God said, Let there be light: and",
+		"                      there was light.",
+		"  Douay-Rheims Bible: And God said: Be light made. And light",
+		"                      was made.",
+		"  JPS Tanakh 1917:    [omitted in this translation]",
+	}
+	if got := renderVerses(texts, 60, style{}); !slices.Equal(got, want) {
+		t.Errorf("renderVerses at 60 columns =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	// labels that would take more than half the line go above their texts
+	want = []string{
+		"  King James Bible:",
+		"    And God said, Let there be",
+		"    light: and there was light.",
+		"  Douay-Rheims Bible:",
+		"    And God said: Be light made.",
+		"    And light was made.",
+		"  JPS Tanakh 1917:",
+		"    [omitted in this translation]",
+	}
+	if got := renderVerses(texts, 32, style{}); !slices.Equal(got, want) {
+		t.Errorf("renderVerses at 32 columns =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	// with color, the words the first text does not have are highlighted
+	if got := renderVerses(texts[:2], 80, style{color: true}); !strings.Contains(got[1], "light \x1b[33mmade.\x1b[0m And light \x1b[33mwas\x1b[0m") || strings.Contains(got[0], "\x1b[33m") {
+		t.Errorf("renderVerses with color = %q", got)
+	}
+}
+
+func TestNewStyle(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "out.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	t.Setenv("NO_COLOR", "")
+	if newStyle(file).color {
+		t.Error("a file is written with color")
+	}
+	if got := (style{}).label("King James Bible"); got != "King James Bible" {
+		t.Errorf("the plain style marked up a label as %q", got)
+	}
+}
@@ -0,0 +1,367 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/botanyhelp/goBibleVerseComparer/bible"
+)
+
+// errQuit is returned by readLine when the user types quit or the input ends
+var errQuit = errors.New("quit")
+
+// repl is the interactive prompt, and the batch mode that looks up one
+// reference per line.  It reads only from in and writes only to out, so
+// it can be driven by a script or a test as well as by a person.
+type repl struct {
+	in  *bufio.Reader
+	out io.Writer
+
+	// loaded are every translation read so far, and translations the ones shown
+	loaded       []*bible.Translation
+	translations []*bible.Translation
+	// catalogs are where use and add find translations that are not loaded
+	// yet, read into catalog the first time, and cacheDir caches their texts
+	catalogs []string
+	catalog  *bible.Catalog
+	cacheDir string
+	// canon is the name of the canon and canonBooks its books, of which
+	// books are the ones some translation has
+	canon      string
+	canonBooks []string
+	books      []string
+	// ropes number their verses like scheme, the versification references are typed in
+	ropes  []*bible.Rope
+	scheme *bible.Versification
+	layout string
+	// format is text, for people, or one of the structured formats, which
+	// results writes to out
+	format  string
+	results *resultWriter
+	// width is what passages are wrapped to, and height the lines shown
+	// before asking for more; a height of 0 shows everything at once
+	width  int
+	height int
+	// style marks up what is written to out, with color at a terminal
+	style style
+
+	// bookmarksPath is the file the mark command keeps bookmarks in
+	bookmarksPath string
+	// notesPath is the file the note command keeps notes on verses in
+	notesPath string
+	// planPath is the file the plan command keeps the reading plan being
+	// followed in
+	planPath string
+	// now is the time, for the plan and votd commands; nil is time.Now
+	now func() time.Time
+	// rng picks the verses random shows; nil is the shared source
+	rng *rand.Rand
+	// xrefPath is the file the xref command keeps imported cross references
+	// in, and xrefs those cross references, once they are read
+	xrefPath string
+	xrefs    bible.CrossReferences
+
+	// promptHelp explains the prompt being answered, for help typed at it
+	promptHelp string
+
+	// lastShown is the passage shown most recently, which next and previous move from
+	lastShown bible.Passage
+
+	// editor, when not nil, reads lines with editing, history and completion
+	editor *lineEditor
+}
+
+// sayGoodbye prints a goodbye message
+func sayGoodbye(w io.Writer) {
+	fmt.Fprintln(w, "God loves you! Goodbye! Terminating program.")
+}
+
+// schemeRopes are the ropes of the translations that number verses like
+// scheme, which give the valid chapter and verse numbers at the prompts;
+// when none do, the ropes of all of them are used
+func schemeRopes(translations []*bible.Translation, scheme *bible.Versification) []*bible.Rope {
+	var ropes []*bible.Rope
+	for _, translation := range translations {
+		if translation.Versification == scheme {
+			ropes = append(ropes, translation.Rope)
+		}
+	}
+	if len(ropes) == 0 {
+		return bible.Ropes(translations)
+	}
+	return ropes
+}
+
+// readLine prints prompt and returns the line typed, trimmed.  Commands
+// are run and the prompt asked again, unless the command showed a passage,
+// when errDone is returned.  It returns errQuit for quit or the end of the
+// input.  help explains the prompt for the help command, and with a line
+// editor, complete gives the completions for Tab on top of the commands.
+func (r *repl) readLine(prompt, help string, complete func(string) []string) (string, error) {
+	r.promptHelp = help
+	for {
+		line, err := r.readInput(prompt, func(before string) []string {
+			if completions, ok := r.completeCommand(before); ok {
+				return completions
+			}
+			var completions []string
+			if complete != nil {
+				completions = complete(before)
+			}
+			return append(completions, completeWords(strings.TrimLeft(before, " "), commandWords())...)
+		})
+		if err != nil {
+			return "", err
+		}
+		command, args := splitCommand(line)
+		if command == nil {
+			return line, nil
+		}
+		done, err := command.run(r, args)
+		switch {
+		case errors.Is(err, errQuit):
+			return "", errQuit
+		case err != nil:
+			fmt.Fprintf(r.out, "%v\n", err)
+		case done:
+			return "", errDone
+		}
+	}
+}
+
+// readInput prints prompt and reads a line, with the line editor when
+// there is one.  It returns errQuit at the end of the input.
+func (r *repl) readInput(prompt string, complete func(string) []string) (string, error) {
+	var line string
+	var err error
+	if r.editor != nil {
+		line, err = r.editor.readLine(prompt, complete)
+		if err != nil && !errors.Is(err, io.EOF) {
+			// the terminal cannot do raw mode, so go on without the editor
+			r.editor = nil
+		}
+	}
+	if r.editor == nil {
+		fmt.Fprint(r.out, prompt)
+		line, err = r.in.ReadString('\n')
+	}
+	line = strings.TrimSpace(line)
+	if err != nil && line == "" {
+		return "", errQuit
+	}
+	return line, nil
+}
+
+// showPassage prints a single verse as usual, and longer passages with
+// verse numbers, wrapped to width and a screenful at a time
+func (r *repl) showPassage(passage bible.Passage) {
+	if err := r.writePassage(r.out, passage, r.height); err != nil {
+		fmt.Fprintf(r.out, "%v\n", err)
+		return
+	}
+	r.lastShown = passage
+}
+
+// writePassage writes passage to w as showPassage shows it, stopping
+// after height lines for Enter
+func (r *repl) writePassage(w io.Writer, passage bible.Passage, height int) error {
+	refs := passage.VerseRefs(r.ropes)
+	if len(refs) == 0 {
+		return fmt.Errorf("none of the loaded texts have %s", passage)
+	}
+	if r.format != "text" {
+		return r.resultsFor(w).write(passageResults(refs, r.translations, r.scheme))
+	}
+	if passage.IsSingleVerse() {
+		printVerse(w, r.translations, passage.Start(), r.scheme, r.width, r.styleFor(w))
+		if notes := r.notesOn(w); notes != nil {
+			for _, line := range notes(passage.Start()) {
+				fmt.Fprintln(w, line)
+			}
+		}
+		return nil
+	}
+	pageLines(w, renderPassage(passage, refs, r.translations, r.scheme, r.layout, r.width, r.styleFor(w), r.notesOn(w)), height, r.in)
+	return nil
+}
+
+// styleFor returns the style for writing to w: the style of out, and
+// plain text for anything else, like a file being exported to
+func (r *repl) styleFor(w io.Writer) style {
+	if w != r.out {
+		return style{}
+	}
+	return r.style
+}
+
+// resultsFor returns the writer of structured results to w, which is
+// kept for out so its tsv header is only written once
+func (r *repl) resultsFor(w io.Writer) *resultWriter {
+	if w != r.out {
+		return &resultWriter{w: w, format: r.format}
+	}
+	if r.results == nil {
+		r.results = &resultWriter{w: w, format: r.format}
+	}
+	return r.results
+}
+
+// run prompts for a book, chapter and verse, or a whole reference, over
+// and over, and returns when the user quits or the input ends
+func (r *repl) run() error {
+	defer sayGoodbye(r.out)
+	for {
+		if err := r.prompt(); errors.Is(err, errQuit) {
+			return nil
+		} else if err != nil && !errors.Is(err, errDone) {
+			return err
+		}
+	}
+}
+
+// prompt asks for one reference, a book, chapter and verse at a time
+// unless a whole reference is typed at the book prompt, and shows it.
+// It returns errDone when a command showed a passage instead.
+func (r *repl) prompt() error {
+	var book string
+	var goodBookYet bool = false
+	for !goodBookYet {
+		// Prompt for and read the first value
+		var err error
+		book, err = r.readLine("\nType 'quit' or 'help' anytime.\nEnter the book, like 'Genesis' or '2 Corinthians', or a passage, like 'Gen 1' or 'Matt 5:3-12': ", bookPromptHelp(), r.completeReference)
+		if err != nil {
+			return err
+		}
+		// Check if the book provided by user is in the slice
+		if slices.Contains(r.books, book) {
+			goodBookYet = true
+		} else if slices.Contains(r.canonBooks, book) {
+			fmt.Fprintf(r.out, "%s is in the %s canon but none of the loaded texts have it, valid books are shown here:\n", book, r.canon)
+			writeBooks(r.out, r.books, r.width)
+		} else if passage, err := bible.ParseReference(book, r.books); err == nil && passage.StartChapter == 0 {
+			// an abbreviation, like 'Gen', of a valid book
+			book = passage.Book
+			goodBookYet = true
+		} else if err == nil {
+			r.showPassage(passage)
+			return nil
+		} else if errors.Is(err, bible.ErrUnknownBook) {
+			fmt.Fprintf(r.out, "%s is NOT in the list of valid books, which are shown here:\n", book)
+			writeBooks(r.out, r.books, r.width)
+		} else {
+			fmt.Fprintf(r.out, "%v\n", err)
+		}
+	}
+
+	// chapterNumber will hold chapter number which we need later to get set of verse numbers in that chapter
+	var chapterNumber int
+	for goodChapterNumberYet := false; !goodChapterNumberYet; {
+		// Prompt for and read the second value
+		chapterNumberString, err := r.readLine("Enter the chapter number: ", r.chapterPromptHelp(book), func(before string) []string {
+			return completeNumbers(before, bible.ChaptersOf(r.ropes, book))
+		})
+		if err != nil {
+			return err
+		}
+		// the chapters any loaded text has for this book, sorted
+		var chapterSetKeys []int = bible.ChaptersOf(r.ropes, book)
+		// Check if the book provided by user is in the set of chapters for that book
+		chapterNumber, _ = strconv.Atoi(chapterNumberString)
+		if slices.Contains(chapterSetKeys, chapterNumber) {
+			goodChapterNumberYet = true
+		} else {
+			fmt.Fprintf(r.out, "%s is NOT in the list of valid chapters of %s, which are %s\n", chapterNumberString, book, bible.FormatNumberRanges(chapterSetKeys))
+		}
+	}
+
+	for {
+		// Prompt for and read the third value
+		verseNumberString, err := r.readLine("Enter the verse number, a range like '3-12', or 'all' for the whole chapter: ", r.versePromptHelp(book, chapterNumber), func(before string) []string {
+			return append(completeNumbers(before, bible.VersesOf(r.ropes, book, chapterNumber)), completeWords(before, []string{"all"})...)
+		})
+		if err != nil {
+			return err
+		}
+		if verseNumberString == "all" {
+			r.showPassage(bible.ChapterPassage(book, chapterNumber))
+			return nil
+		}
+		if strings.Contains(verseNumberString, "-") {
+			if passage, err := bible.ParseReference(fmt.Sprintf("%s %d:%s", book, chapterNumber, verseNumberString), r.books); err == nil {
+				r.showPassage(passage)
+				return nil
+			}
+		}
+		// the verses any loaded text has for this chapter, sorted
+		var verseSetKeys []int = bible.VersesOf(r.ropes, book, chapterNumber)
+		verseNumber, _ := strconv.Atoi(verseNumberString)
+		if slices.Contains(verseSetKeys, verseNumber) {
+			r.showPassage(bible.VersePassage(bible.VerseRef{Book: book, Chapter: chapterNumber, Verse: verseNumber}))
+			return nil
+		}
+		fmt.Fprintf(r.out, "%s is NOT in the list of valid verse numbers of %s %d, and so please enter one of %s, a range like '3-12', or 'all'\n", verseNumberString, book, chapterNumber, bible.FormatNumberRanges(verseSetKeys))
+	}
+}
+
+// runBatch reads one reference per line, like 'John 3:16' or 'Matt 5:3-12',
+// and shows each one, until the input ends.  Blank lines and lines starting
+// with # are skipped, and the commands work as at the prompt, so quit stops
+// early.  References and commands that fail are reported to errs, and the
+// error returned says how many there were.
+func (r *repl) runBatch(errs io.Writer) error {
+	r.promptHelp = batchHelp()
+	scanner := bufio.NewScanner(r.in)
+	failed, lineNumber := 0, 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if command, args := splitCommand(line); command != nil {
+			done, err := command.run(r, args)
+			if errors.Is(err, errQuit) {
+				break
+			}
+			if err != nil {
+				fmt.Fprintf(errs, "line %d: %v\n", lineNumber, err)
+				failed++
+			} else if done && r.format == "text" {
+				fmt.Fprintln(r.out)
+			}
+			continue
+		}
+		passage, err := bible.ParseReference(line, r.books)
+		if err == nil && passage.StartChapter == 0 {
+			err = fmt.Errorf("%s needs a chapter, like '%s 1'", line, passage.Book)
+		}
+		if err != nil {
+			fmt.Fprintf(errs, "line %d: %v\n", lineNumber, err)
+			failed++
+			continue
+		}
+		if err := r.writePassage(r.out, passage, r.height); err != nil {
+			fmt.Fprintf(errs, "line %d: %v\n", lineNumber, err)
+			failed++
+			continue
+		}
+		r.lastShown = passage
+		if r.format == "text" {
+			fmt.Fprintln(r.out)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of the references could not be shown", failed)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/botanyhelp/goBibleVerseComparer/bible"
+)
+
+// newTestRepl returns a repl over the fixture bibles reading input
+func newTestRepl(t *testing.T, input string) (*repl, *bytes.Buffer) {
+	t.Helper()
+	translations := loadFixtures(t)
+	books, err := bible.CanonBooks("protestant", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// like main, the prompts only use the ropes numbered like the KJV
+	ropes := []*bible.Rope{translations[0].Rope}
+	var out bytes.Buffer
+	return &repl{
+		in:           bufio.NewReader(strings.NewReader(input)),
+		out:          &out,
+		loaded:       translations,
+		translations: translations,
+		canon:        "protestant",
+		canonBooks:   books,
+		books:        bible.ValidBooksFor(books, ropes),
+		ropes:        ropes,
+		scheme:       bible.KJV,
+		layout:       "interleaved",
+		format:       "text",
+		width:        80,
+	}, &out
+}
+
+func TestReplRun(t *testing.T) {
+	tests := []struct {
+		name, input string
+		want        []string
+	}{
+		{"reference", "John 3:16\nquit\n", []string{"John 3:16\n  King James Bible:   For God so loved the world, that he gave", "Goodbye"}},
+		{"three prompts", "Genesis\n1\n3\nquit\n", []string{"Enter the chapter number: ", "Genesis 1:3\n  King James Bible:   And God said, Let there be light"}},
+		{"abbreviation", "mal\n4\n5\n", []string{"Malachi 4:5\n", "  JPS Tanakh 1917:    Behold, I will send you Elijah", "[Malachi 3:23]"}},
+		{"range", "Gen\n1\n1-2\n", []string{"Genesis 1:1-2\n", "1:2\n  King James Bible:   And the earth"}},
+		{"bad chapter", "Gen\n7\n1\n1\n", []string{"7 is NOT in the list of valid chapters of Genesis, which are 1\n"}},
+		{"next", "Gen 1:1\nn\n", []string{"Genesis 1:2\n  King James Bible:   And the earth was without form"}},
+		{"a verse not there", "Gen 1:2\nJohn 3:17\nn\n", []string{"none of the loaded texts have John 3:17\n", "Genesis 1:3\n  King James Bible:   And God said"}},
+		{"verse 0", "Gen 1:0\n", []string{`"Gen 1:0" is not a reference, as chapters and verses start at 1`}},
+		{"bad verse", "Ps\n23\n9\n1\n", []string{"9 is NOT in the list of valid verse numbers of Psalm 23, and so please enter one of 1–2, a range"}},
+		{"not a book", "Hezekiah\nquit\n", []string{"Hezekiah is NOT in the list of valid books, which are shown here:\nOld Testament:\n  Genesis"}},
+		{"not a reference", "John 3:16:2\n", []string{`"John 3:16:2" is not a reference like 'John 3:16'`}},
+		{"quit at a verse prompt", "Gen\n1\nquit\nJohn 3:16\n", []string{"Goodbye"}},
+		{"end of input", "John 3:16", []string{"John 3:16\n", "Goodbye"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			prompter, out := newTestRepl(t, test.input)
+			if err := prompter.run(); err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range test.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output does not have %q:\n%s", want, out)
+				}
+			}
+			if test.name == "quit at a verse prompt" && strings.Contains(out.String(), "For God so loved") {
+				t.Errorf("kept going after quit:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestReplRunBatch(t *testing.T) {
+	prompter, out := newTestRepl(t, "# a list of references\nJohn 3:16\n\nGen 1:2-3\nn\nHezekiah 1:1\nGenesis\nuse kjv\ndrop xyz\nJohn 3:17\nGen 1:0\n")
+	var errs bytes.Buffer
+	err := prompter.runBatch(&errs)
+	if err == nil || !strings.Contains(err.Error(), "5 of the references") {
+		t.Errorf("err = %v, want 5 references that could not be shown", err)
+	}
+	for _, want := range []string{"John 3:16\n  King James Bible:   For God so loved", "Genesis 1:2-3\n", "Psalm 23:1\n  King James Bible:   The LORD [is] my shepherd", "Showing King James Bible\n"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output does not have %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out.String(), "Enter") {
+		t.Errorf("batch mode prompted:\n%s", out)
+	}
+	for _, want := range []string{"line 6: Hezekiah", "line 7: Genesis needs a chapter", "line 9: xyz is not shown", "line 10: none of the loaded texts have John 3:17", `line 11: "Gen 1:0" is not a reference, as chapters and verses start at 1`} {
+		if !strings.Contains(errs.String(), want) {
+			t.Errorf("errors do not have %q:\n%s", want, errs.String())
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/botanyhelp/goBibleVerseComparer/bible"
+)
+
+// apiServer answers the JSON API from bibles that were loaded once at
+// startup.  Nothing changes them after that, so requests share them freely.
+type apiServer struct {
+	translations []*bible.Translation
+	// books are the valid books in canonical order
+	books []string
+	// ropes number their verses like scheme and give the valid chapters and verses
+	ropes  []*bible.Rope
+	scheme *bible.Versification
+}
+
+// routes returns the handler for every API endpoint and the web page
+func (s *apiServer) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/translations", s.handleTranslations)
+	mux.HandleFunc("GET /api/books", s.handleBooks)
+	mux.HandleFunc("GET /api/passage", s.handlePassage)
+	mux.HandleFunc("GET /api/search", s.handleSearch)
+	mux.HandleFunc("GET /api/diff", s.handleDiff)
+	mux.Handle("GET /", webUIHandler())
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the API answers with JSON even when it is asked the wrong thing
+		if strings.HasPrefix(r.URL.Path, "/api/") {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				w.Header().Set("Allow", "GET, HEAD")
+				writeError(w, http.StatusMethodNotAllowed, "%s is not allowed on %s, only GET", r.Method, r.URL.Path)
+				return
+			}
+			if _, pattern := mux.Handler(r); !strings.HasPrefix(pattern, "GET /api/") {
+				writeError(w, http.StatusNotFound, "there is no %s in the API", r.URL.Path)
+				return
+			}
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// writeJSON writes value as the JSON response with the given status code
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		log.Printf("Error writing response: %v", err)
+	}
+}
+
+// writeError writes {"error": message} with the given status code
+func writeError(w http.ResponseWriter, status int, format string, args ...any) {
+	writeJSON(w, status, map[string]string{"error": fmt.Sprintf(format, args...)})
+}
+
+// selectTranslations returns the translations named by the comma separated
+// codes, in that order, or every translation when codes is empty
+func (s *apiServer) selectTranslations(codes string) ([]*bible.Translation, error) {
+	if strings.TrimSpace(codes) == "" {
+		return s.translations, nil
+	}
+	var selected []*bible.Translation
+	for _, code := range strings.Split(codes, ",") {
+		translation := bible.FindTranslation(s.translations, code)
+		if translation == nil {
+			return nil, fmt.Errorf("unknown translation %q", strings.TrimSpace(code))
+		}
+		selected = append(selected, translation)
+	}
+	return selected, nil
+}
+
+// passageRefs parses the ref query parameter and returns the passage and
+// its verses, with the HTTP status to use when it is no good
+func (s *apiServer) passageRefs(r *http.Request) (bible.Passage, []bible.VerseRef, int, error) {
+	ref := r.URL.Query().Get("ref")
+	if ref == "" {
+		return bible.Passage{}, nil, http.StatusBadRequest, fmt.Errorf("the ref parameter is required, like ref=John+3:16")
+	}
+	passage, err := bible.ParseReference(ref, s.books)
+	if err != nil {
+		return bible.Passage{}, nil, http.StatusNotFound, err
+	}
+	if passage.StartChapter == 0 {
+		return bible.Passage{}, nil, http.StatusBadRequest, fmt.Errorf("%s needs a chapter, like %s 1", passage.Book, passage.Book)
+	}
+	refs := passage.VerseRefs(s.ropes)
+	if len(refs) == 0 {
+		return bible.Passage{}, nil, http.StatusNotFound, fmt.Errorf("none of the loaded translations have %s", passage)
+	}
+	return passage, refs, http.StatusOK, nil
+}
+
+// handleTranslations lists the loaded translations
+func (s *apiServer) handleTranslations(w http.ResponseWriter, r *http.Request) {
+	type translationInfo struct {
+		Code          string `json:"code"`
+		Title         string `json:"title"`
+		Versification string `json:"versification"`
+		Books         int    `json:"books"`
+	}
+	var infos []translationInfo
+	for _, translation := range s.translations {
+		infos = append(infos, translationInfo{translation.Code, translation.Title, translation.Versification.Name, len(translation.Rope.Books)})
+	}
+	writeJSON(w, http.StatusOK, infos)
+}
+
+// handleBooks lists the books that may be looked up, in canonical order
+func (s *apiServer) handleBooks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.books)
+}
+
+// handlePassage returns every verse of ref from the translations in t,
+// like /api/passage?ref=Matt+5:3-12&t=asv,kjv
+func (s *apiServer) handlePassage(w http.ResponseWriter, r *http.Request) {
+	translations, err := s.selectTranslations(r.URL.Query().Get("t"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "%v", err)
+		return
+	}
+	passage, refs, status, err := s.passageRefs(r)
+	if err != nil {
+		writeError(w, status, "%v", err)
+		return
+	}
+	type verse struct {
+		Reference    string              `json:"reference"`
+		Translations []bible.VerseResult `json:"translations"`
+	}
+	var verses []verse
+	for _, ref := range refs {
+		verses = append(verses, verse{ref.String(), bible.Compare(translations, ref, s.scheme)})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"reference": passage.String(), "verses": verses})
+}
+
+// handleSearch returns the verses that hold every term of q, like
+// /api/search?q=living+water&t=kjv&limit=20
+func (s *apiServer) handleSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if len(bible.SearchTerms(query)) == 0 {
+		writeError(w, http.StatusBadRequest, "the q parameter is required, like q=living+water")
+		return
+	}
+	translations, err := s.selectTranslations(r.URL.Query().Get("t"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "%v", err)
+		return
+	}
+	limit := 100
+	if limitString := r.URL.Query().Get("limit"); limitString != "" {
+		if limit, err = strconv.Atoi(limitString); err != nil || limit < 1 {
+			writeError(w, http.StatusBadRequest, "limit must be a number above 0")
+			return
+		}
+	}
+	results := searchResults(bible.SearchVerses(translations, query, s.books, limit))
+	writeJSON(w, http.StatusOK, map[string]any{"query": query, "results": results})
+}
+
+// handleDiff compares ref word by word between translations a and b,
+// like /api/diff?ref=John+3:16&a=kjv&b=asv
+func (s *apiServer) handleDiff(w http.ResponseWriter, r *http.Request) {
+	a := bible.FindTranslation(s.translations, r.URL.Query().Get("a"))
+	b := bible.FindTranslation(s.translations, r.URL.Query().Get("b"))
+	if a == nil || b == nil {
+		writeError(w, http.StatusNotFound, "a and b must both be codes of loaded translations")
+		return
+	}
+	passage, refs, status, err := s.passageRefs(r)
+	if err != nil {
+		writeError(w, status, "%v", err)
+		return
+	}
+	type verseDiff struct {
+		Reference string         `json:"reference"`
+		Ops       []bible.DiffOp `json:"ops"`
+	}
+	var diffs []verseDiff
+	for _, ref := range refs {
+		aText, _, _ := a.LookupVerse(ref, s.scheme)
+		bText, _, _ := b.LookupVerse(ref, s.scheme)
+		diffs = append(diffs, verseDiff{ref.String(), bible.DiffWords(aText, bText)})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"reference": passage.String(), "a": a.Code, "b": b.Code, "verses": diffs})
+}
+
+// serve answers the API on addr until the process is interrupted, then
+// lets the requests in flight finish before returning
+func serve(addr string, handler http.Handler) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	server := &http.Server{Addr: addr, Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	serverErrors := make(chan error, 1)
+	go func() {
+		serverErrors <- server.ListenAndServe()
+	}()
+	fmt.Printf("Serving on %s, press Ctrl+C to stop\n", addr)
+	select {
+	case err := <-serverErrors:
+		return err
+	case <-ctx.Done():
+	}
+	fmt.Println("Shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
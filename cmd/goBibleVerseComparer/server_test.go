@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/botanyhelp/goBibleVerseComparer/bible"
+)
+
+// newTestServer serves the API from the fixture bibles, numbered like the KJV
+func newTestServer(t *testing.T) http.Handler {
+	t.Helper()
+	translations := loadFixtures(t)
+	books, err := bible.CanonBooks("protestant", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ropes := []*bible.Rope{translations[0].Rope}
+	api := &apiServer{translations: translations, books: bible.ValidBooksFor(books, ropes), ropes: ropes, scheme: bible.KJV}
+	return api.routes()
+}
+
+func TestAPI(t *testing.T) {
+	handler := newTestServer(t)
+	tests := []struct {
+		name, method, target string
+		status               int
+		// want are pieces of the JSON body, and wantError the error in it
+		want      []string
+		wantError string
+	}{
+		{"translations", "GET", "/api/translations", http.StatusOK, []string{`{"code":"kjv","title":"King James Bible","versification":"kjv","books":5}`}, ""},
+		{"books", "GET", "/api/books", http.StatusOK, []string{`["Genesis","Psalm","Malachi","John","3 John"]`}, ""},
+		{"passage", "GET", "/api/passage?ref=Gen+1:1-2&t=kjv,drb", http.StatusOK, []string{`"reference":"Genesis 1:1-2"`, `"reference":"Genesis 1:2"`, `In the beginning God created heaven, and earth.`}, ""},
+		{"passage in every translation", "GET", "/api/passage?ref=John+3:16", http.StatusOK, []string{`King James Bible`, `JPS Tanakh 1917`, `Douay-Rheims Bible`}, ""},
+		{"search", "GET", "/api/search?q=light&t=kjv", http.StatusOK, []string{`"query":"light"`, `"reference":"Genesis 1:3"`}, ""},
+		{"diff", "GET", "/api/diff?ref=John+3:16&a=kjv&b=drb", http.StatusOK, []string{`"a":"kjv","b":"drb"`, `"reference":"John 3:16"`, `"ops":[`}, ""},
+		{"passage in an unknown translation", "GET", "/api/passage?ref=John+3:16&t=kjv,xyz", http.StatusNotFound, nil, `unknown translation "xyz"`},
+		{"search an unknown translation", "GET", "/api/search?q=light&t=xyz", http.StatusNotFound, nil, `unknown translation "xyz"`},
+		{"diff an unknown translation", "GET", "/api/diff?ref=John+3:16&a=kjv&b=xyz", http.StatusNotFound, nil, "a and b must both be codes of loaded translations"},
+		{"a book not there", "GET", "/api/passage?ref=Hezekiah+1:1", http.StatusNotFound, nil, "Hezekiah"},
+		{"a verse not there", "GET", "/api/passage?ref=John+3:17", http.StatusNotFound, nil, "none of the loaded translations have John 3:17"},
+		{"a book without a chapter", "GET", "/api/diff?ref=John&a=kjv&b=drb", http.StatusBadRequest, nil, "John needs a chapter"},
+		{"no ref", "GET", "/api/passage", http.StatusBadRequest, nil, "the ref parameter is required"},
+		{"no q", "GET", "/api/search?t=kjv", http.StatusBadRequest, nil, "the q parameter is required"},
+		{"a bad limit", "GET", "/api/search?q=light&limit=none", http.StatusBadRequest, nil, "limit must be a number above 0"},
+		{"no such endpoint", "GET", "/api/verses?ref=John+3:16", http.StatusNotFound, nil, "there is no /api/verses in the API"},
+		{"the wrong method", "POST", "/api/passage?ref=John+3:16", http.StatusMethodNotAllowed, nil, "POST is not allowed on /api/passage, only GET"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(test.method, test.target, nil))
+			body := recorder.Body.String()
+			if recorder.Code != test.status {
+				t.Errorf("status %d, want %d: %s", recorder.Code, test.status, body)
+			}
+			if contentType := recorder.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "application/json") {
+				t.Errorf("Content-Type %q, want JSON", contentType)
+			}
+			var response map[string]any
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil && test.wantError != "" {
+				t.Fatalf("the body is not a JSON object: %v: %s", err, body)
+			}
+			if errorText, _ := response["error"].(string); !strings.Contains(errorText, test.wantError) || (test.wantError == "" && errorText != "") {
+				t.Errorf("error %q, want %q", errorText, test.wantError)
+			}
+			for _, want := range test.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("body does not have %s: %s", want, body)
+				}
+			}
+		})
+	}
+}
+
+func TestAPIServesTheWebPage(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	newTestServer(t).ServeHTTP(recorder, httptest.NewRequest("GET", "/", nil))
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), "<html") {
+		t.Errorf("GET / = %d, want the web page: %.200s", recorder.Code, recorder.Body.String())
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/botanyhelp/goBibleVerseComparer/bible"
+)
+
+// style marks up text for a terminal with ANSI escape codes.  The zero
+// style leaves text plain, as it must be for files, pipes and NO_COLOR.
+type style struct {
+	color bool
+}
+
+// newStyle returns the style for writing to f: colored when f is a
+// terminal, unless NO_COLOR is set (see no-color.org) or TERM is dumb
+func newStyle(f *os.File) style {
+	return style{color: isTerminal(f) && os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb"}
+}
+
+// wrap puts text between the escape code and a reset, if the style has color
+func (s style) wrap(code, text string) string {
+	if !s.color || text == "" {
+		return text
+	}
+	return "\x1b[" + code + "m" + text + "\x1b[0m"
+}
+
+// heading is for references, like "John 3:16" above a verse
+func (s style) heading(text string) string { return s.wrap("1", text) }
+
+// label is for the names of translations
+func (s style) label(text string) string { return s.wrap("1;36", text) }
+
+// changed is for words a translation does not share with the first one shown
+func (s style) changed(text string) string { return s.wrap("33", text) }
+
+// removed and added are for the words diff marks
+func (s style) removed(text string) string { return s.wrap("31", text) }
+func (s style) added(text string) string   { return s.wrap("32", text) }
+
+// note is for what is said about a verse rather than its text, like
+// "[omitted in this translation]" or the number a translation gives it
+func (s style) note(text string) string { return s.wrap("2", text) }
+
+// highlightWords returns the words of text, and the same words styled so
+// those that base does not have stand out.  With no color, or no base to
+// compare with, the styled words are the words.
+func (s style) highlightWords(base, text string) ([]string, []string) {
+	words := strings.Fields(text)
+	if !s.color || base == "" || base == text {
+		return words, words
+	}
+	styled := make([]string, 0, len(words))
+	for _, op := range bible.DiffWords(base, text) {
+		for _, word := range strings.Fields(op.Text) {
+			switch op.Op {
+			case "insert":
+				styled = append(styled, s.changed(word))
+			case "equal":
+				styled = append(styled, word)
+			}
+		}
+	}
+	return words, styled
+}
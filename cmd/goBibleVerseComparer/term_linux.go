@@ -0,0 +1,9 @@
+package main
+
+import "syscall"
+
+// the ioctl requests that get and set the terminal settings
+const (
+	ioctlGetTermios = syscall.TCGETS
+	ioctlSetTermios = syscall.TCSETS
+)
@@ -0,0 +1,15 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package main
+
+import "errors"
+
+// makeRaw is not supported here, so the prompts read whole lines as typed
+func makeRaw(fd uintptr) (func(), error) {
+	return nil, errors.New("line editing is not supported on this system")
+}
+
+// windowSize is not supported here, so the size comes from COLUMNS and LINES
+func windowSize(fd uintptr) (width, height int, ok bool) {
+	return 0, 0, false
+}
@@ -0,0 +1,160 @@
+// app.js drives the comparison page: it fills in the book names and the
+// translation checkboxes from the JSON API, shows the chosen passage side
+// by side, and keeps the reference and translations in the page URL so a
+// comparison can be shared.
+"use strict";
+
+const form = document.getElementById("lookup");
+const refInput = document.getElementById("ref");
+const booksList = document.getElementById("books");
+const translationsBox = document.getElementById("translations");
+const message = document.getElementById("message");
+const referenceHeading = document.getElementById("reference");
+const columns = document.getElementById("columns");
+
+// getJSON fetches an API path and returns the decoded body, throwing the
+// API's error message when the status is not OK
+async function getJSON(path) {
+  const response = await fetch(path);
+  const body = await response.json();
+  if (!response.ok) {
+    throw new Error(body.error || response.statusText);
+  }
+  return body;
+}
+
+// checkedCodes returns the codes of the checked translations in page order
+function checkedCodes() {
+  return Array.from(translationsBox.querySelectorAll("input:checked")).map((box) => box.value);
+}
+
+// showMessage puts text in the status line, or clears it
+function showMessage(text) {
+  message.textContent = text || "";
+}
+
+// verseElement builds one verse paragraph; ops, when given, are the word
+// diff against the first translation and inserted words are highlighted
+function verseElement(reference, result, ops) {
+  const p = document.createElement("p");
+  p.className = "verse";
+  const number = document.createElement("sup");
+  number.textContent = reference.split(" ").pop();
+  p.appendChild(number);
+  if (!result.found) {
+    p.classList.add("missing");
+    p.appendChild(document.createTextNode("[omitted in this translation]"));
+    return p;
+  }
+  if (!ops) {
+    p.appendChild(document.createTextNode(result.text));
+    return p;
+  }
+  ops.filter((op) => op.op !== "delete").forEach((op, i) => {
+    if (i > 0) {
+      p.appendChild(document.createTextNode(" "));
+    }
+    if (op.op === "insert") {
+      const mark = document.createElement("mark");
+      mark.textContent = op.text;
+      p.appendChild(mark);
+    } else {
+      p.appendChild(document.createTextNode(op.text));
+    }
+  });
+  return p;
+}
+
+// compare looks up the reference in the checked translations and shows
+// one column per translation
+async function compare() {
+  const ref = refInput.value.trim();
+  const codes = checkedCodes();
+  if (!ref || codes.length === 0) {
+    showMessage("Type a reference and check at least one translation.");
+    return;
+  }
+  const params = new URLSearchParams({ ref: ref, t: codes.join(",") });
+  history.replaceState(null, "", "?" + params.toString());
+  try {
+    const passage = await getJSON("/api/passage?" + params.toString());
+    // diff every other translation against the first one checked
+    const diffs = {};
+    await Promise.all(codes.slice(1).map(async (code) => {
+      const diff = await getJSON("/api/diff?" + new URLSearchParams({ ref: ref, a: codes[0], b: code }).toString());
+      diffs[code] = new Map(diff.verses.map((verse) => [verse.reference, verse.ops]));
+    }));
+    showMessage("");
+    referenceHeading.textContent = passage.reference;
+    columns.replaceChildren();
+    codes.forEach((code, index) => {
+      const column = document.createElement("section");
+      column.className = "column";
+      const heading = document.createElement("h3");
+      column.appendChild(heading);
+      passage.verses.forEach((verse) => {
+        const result = verse.translations[index];
+        heading.textContent = result.title;
+        const ops = diffs[code] ? diffs[code].get(verse.reference) : null;
+        column.appendChild(verseElement(verse.reference, result, ops));
+      });
+      columns.appendChild(column);
+    });
+  } catch (error) {
+    showMessage(error.message);
+  }
+}
+
+// load fills in the books and translations, then restores a shared
+// comparison from the page URL
+async function load() {
+  const params = new URLSearchParams(location.search);
+  const wanted = (params.get("t") || "").split(",").filter((code) => code);
+  try {
+    const [books, translations] = await Promise.all([getJSON("/api/books"), getJSON("/api/translations")]);
+    books.forEach((book) => {
+      const option = document.createElement("option");
+      option.value = book;
+      booksList.appendChild(option);
+    });
+    translations.forEach((translation, index) => {
+      const label = document.createElement("label");
+      const box = document.createElement("input");
+      box.type = "checkbox";
+      box.value = translation.code;
+      box.checked = wanted.length > 0 ? wanted.includes(translation.code) : index < 2;
+      label.appendChild(box);
+      label.appendChild(document.createTextNode(" " + translation.title + " (" + translation.code + ")"));
+      translationsBox.appendChild(label);
+    });
+  } catch (error) {
+    showMessage(error.message);
+    return;
+  }
+  if (params.get("ref")) {
+    refInput.value = params.get("ref");
+    compare();
+  }
+}
+
+form.addEventListener("submit", (event) => {
+  event.preventDefault();
+  compare();
+});
+
+translationsBox.addEventListener("change", () => {
+  if (refInput.value.trim()) {
+    compare();
+  }
+});
+
+document.getElementById("share").addEventListener("click", async () => {
+  try {
+    await navigator.clipboard.writeText(location.href);
+    showMessage("Link copied.");
+  } catch (error) {
+    showMessage("Copy this link: " + location.href);
+  }
+});
+
+load();
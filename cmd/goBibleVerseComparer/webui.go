@@ -0,0 +1,23 @@
+package main
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// webFiles holds the comparison page, its script and its styles, built
+// into the binary so the page works offline with no other files around
+//
+//go:embed web
+var webFiles embed.FS
+
+// webUIHandler serves the embedded comparison page and its assets
+func webUIHandler() http.Handler {
+	webRoot, err := fs.Sub(webFiles, "web")
+	if err != nil {
+		// only possible if the embed directive above names a missing directory
+		panic(err)
+	}
+	return http.FileServerFS(webRoot)
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/botanyhelp/goBibleVerseComparer/bible"
+)
+
+// xrefCommands are the words that may follow xref instead of a reference
+var xrefCommands []string = []string{"import", "show"}
+
+// xrefsListed and xrefsShown are how many cross references xref lists and
+// xref show shows when not told how many
+const (
+	xrefsListed = 20
+	xrefsShown  = 5
+)
+
+// crossReferencesPath is the file imported cross references are kept in
+func crossReferencesPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "cross_references.tsv"), nil
+}
+
+// crossReferences returns the imported cross references, reading them
+// the first time they are needed
+func (r *repl) crossReferences() (bible.CrossReferences, error) {
+	if r.xrefs != nil {
+		return r.xrefs, nil
+	}
+	file, err := os.Open(r.xrefPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("no cross references have been imported; download them from https://www.openbible.info/labs/cross-references/ and unzip them, then 'xref import cross_references.txt'")
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	if r.xrefs, err = bible.ParseCrossReferences(file); err != nil {
+		return nil, fmt.Errorf("cross references file %s: %w", r.xrefPath, err)
+	}
+	return r.xrefs, nil
+}
+
+// completeXrefCommands completes the word after xref, which is one of
+// its commands or the book of a reference
+func completeXrefCommands(r *repl, args string) []string {
+	if strings.HasPrefix(args, "import ") {
+		return nil
+	}
+	return completeWords(args, append(append([]string{}, xrefCommands...), r.books...))
+}
+
+func (r *repl) xrefCommand(args string) (bool, error) {
+	if r.xrefPath == "" {
+		return false, fmt.Errorf("cross references cannot be imported, as there is no config directory")
+	}
+	words := strings.Fields(args)
+	if len(words) > 0 && words[0] == "import" {
+		return false, r.xrefImport(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(args), "import")))
+	}
+	show := len(words) > 0 && words[0] == "show"
+	if show {
+		words = words[1:]
+	}
+	passage, words, err := r.leadingPassage(words)
+	if err != nil {
+		return false, err
+	}
+	count := xrefsListed
+	if show {
+		count = xrefsShown
+	}
+	if len(words) > 0 {
+		if count, err = strconv.Atoi(strings.Join(words, " ")); err != nil || count < 1 {
+			return false, fmt.Errorf("%q is not how many cross references to give", strings.Join(words, " "))
+		}
+	}
+
+	xrefs, err := r.crossReferences()
+	if err != nil {
+		return false, err
+	}
+	var kjvRefs []bible.VerseRef
+	for _, ref := range passage.VerseRefs(r.ropes) {
+		kjvRefs = append(kjvRefs, r.scheme.ToKJV(ref)...)
+	}
+	related := xrefs.For(kjvRefs)
+	if len(related) == 0 {
+		fmt.Fprintf(r.out, "No cross references of %s have been imported\n", passage)
+		return false, nil
+	}
+	more := len(related) - count
+	related = related[:min(count, len(related))]
+
+	if show {
+		for i, xref := range related {
+			to := r.fromKJV(xref.To)
+			if r.format == "text" {
+				if i > 0 {
+					fmt.Fprintln(r.out)
+				}
+				fmt.Fprintln(r.out, r.style.note(fmt.Sprintf("Cross reference %d of %s, %s", i+1, passage, votes(xref.Votes))))
+			}
+			r.showPassage(to)
+		}
+		return true, nil
+	}
+	fmt.Fprintf(r.out, "Cross references of %s, most votes first:\n", r.style.heading(passage.String()))
+	w := tabwriter.NewWriter(r.out, 0, 4, 2, ' ', 0)
+	for _, xref := range related {
+		fmt.Fprintf(w, "  %s\t%s\n", r.fromKJV(xref.To), votes(xref.Votes))
+	}
+	if err := w.Flush(); err != nil {
+		return false, err
+	}
+	if more > 0 {
+		fmt.Fprintf(r.out, "and %d more; 'xref %s %d' lists them all, and 'xref show %s' shows the first %d\n", more, passage, count+more, passage, xrefsShown)
+	}
+	return false, nil
+}
+
+// votes writes a number of votes, like "1 vote" or "12 votes"
+func votes(n int) string {
+	if n == 1 || n == -1 {
+		return fmt.Sprintf("%d vote", n)
+	}
+	return fmt.Sprintf("%d votes", n)
+}
+
+// fromKJV renumbers passage, which is numbered like the KJV, like the
+// prompts
+func (r *repl) fromKJV(passage bible.Passage) bible.Passage {
+	starts := r.scheme.FromKJV(passage.Start())
+	ends := r.scheme.FromKJV(bible.VerseRef{Book: passage.Book, Chapter: passage.EndChapter, Verse: passage.EndVerse})
+	if len(starts) == 0 || len(ends) == 0 {
+		return passage
+	}
+	start, end := starts[0], ends[len(ends)-1]
+	return bible.Passage{Book: start.Book, StartChapter: start.Chapter, StartVerse: start.Verse, EndChapter: end.Chapter, EndVerse: end.Verse}
+}
+
+// xrefImport reads the cross references in the file at path and, when
+// they are all understood, keeps a copy of it for xref
+func (r *repl) xrefImport(path string) error {
+	if path == "" {
+		return fmt.Errorf("give the file of cross references to import, like 'xref import cross_references.txt'")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	xrefs, err := bible.ParseCrossReferences(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.xrefPath), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(r.xrefPath, data, 0o644); err != nil {
+		return err
+	}
+	r.xrefs = xrefs
+	fmt.Fprintf(r.out, "Imported the cross references of %d verses from %s\n", len(xrefs), path)
+	return nil
+}